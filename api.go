@@ -2,6 +2,7 @@ package blokus
 
 import (
 	"context"
+	"fmt"
 )
 
 // GameID is the ID of a game.
@@ -21,6 +22,40 @@ type PlayerOptions struct {
 	// StartPos is the starting coordinate for the player.
 	// If nil, the game will automatically choose the next free corner position.
 	StartPos *Coord
+
+	// Colors are the colors of a user who plays several colors, each with its own turn.
+	// The colors start from free corners, diagonally opposite where possible. If set, Color and StartPos are ignored.
+	Colors []Color
+}
+
+// Join adds the user to the game with the options, as Service.AddPlayer does.
+// With several colors, the players are put in the standard turn order of colors.
+func (g *Game) Join(username string, opts *PlayerOptions) error {
+	if opts == nil {
+		opts = &PlayerOptions{}
+	}
+	if len(opts.Colors) > 1 && len(g.Moves) > 0 {
+		// The players couldn't be put in turn order.
+		return fmt.Errorf("Cannot add several colors after moves were played")
+	}
+	if len(opts.Colors) > 0 {
+		if err := g.AddParticipantColors(username, opts.Colors); err != nil {
+			return err
+		}
+		if len(opts.Colors) > 1 {
+			// Make sure the user doesn't play twice in a row.
+			return g.OrderPlayersByColor()
+		}
+		return nil
+	}
+	if opts.StartPos != nil {
+		return g.AddPlayer(username, opts.Color, *opts.StartPos)
+	}
+	startPos, err := g.GetNextFreeCorner()
+	if err != nil {
+		return err
+	}
+	return g.AddPlayer(username, opts.Color, startPos)
 }
 
 type Service interface {
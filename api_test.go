@@ -2,11 +2,15 @@ package blokus
 
 import (
 	"context"
+	"strings"
 	"testing"
 )
 
+var _ Service = &DummyService{}
+
 type DummyService struct {
 	gameID GameID
+	game   *Game
 }
 
 func (d *DummyService) CreateGame(ctx context.Context, username, gamename string, boardSize int, opt *GameOptions) (GameID, error) {
@@ -14,7 +18,7 @@ func (d *DummyService) CreateGame(ctx context.Context, username, gamename string
 }
 
 func (d *DummyService) AddPlayer(ctx context.Context, id GameID, username string, opt *PlayerOptions) error {
-	return nil
+	return d.game.Join(username, opt)
 }
 
 func (d *DummyService) StartGame(ctx context.Context, id GameID, username string) error {
@@ -29,8 +33,12 @@ func (d *DummyService) GetGameState(ctx context.Context, id GameID) error {
 	return nil
 }
 
-func newDummyService(id GameID) Service {
-	return &DummyService{gameID: id}
+func newDummyService(id GameID) *DummyService {
+	g, err := NewGame(20, DefaultPieces())
+	if err != nil {
+		panic(err)
+	}
+	return &DummyService{gameID: id, game: g}
 }
 
 func TestCreateGameWithoutOptions(t *testing.T) {
@@ -74,3 +82,49 @@ func TestAddPlayerWithOptions(t *testing.T) {
 		t.Errorf("AddPlayer: got err %v, want no error", err)
 	}
 }
+
+func TestAddPlayerWithMultipleColors(t *testing.T) {
+	s := newDummyService(1234)
+	if err := s.AddPlayer(context.Background(), GameID(1234), "other_user", &PlayerOptions{Color: Yellow}); err != nil {
+		t.Fatalf("AddPlayer(other_user): got err %v, want no error", err)
+	}
+	opts := &PlayerOptions{
+		Colors: []Color{Red, Blue},
+	}
+	if err := s.AddPlayer(context.Background(), GameID(1234), "some_user", opts); err != nil {
+		t.Fatalf("AddPlayer(some_user): got err %v, want no error", err)
+	}
+	// Players are in turn order of colors, and some_user's colors start from opposite corners.
+	for i, want := range []struct {
+		owner    string
+		color    Color
+		startPos Coord
+	}{
+		{"some_user", Blue, Coord{19, 0}},
+		{"other_user", Yellow, Coord{0, 0}},
+		{"some_user", Red, Coord{0, 19}},
+	} {
+		p := s.game.Players[i]
+		if p.Participant() != want.owner || p.Color != want.color || p.StartPos != want.startPos {
+			t.Errorf("Player %d: got %v %v at %v, want %v %v at %v", i, p.Participant(), p.Color, p.StartPos, want.owner, want.color, want.startPos)
+		}
+	}
+	if err := s.AddPlayer(context.Background(), GameID(1234), "third_user", &PlayerOptions{Colors: []Color{Green, Yellow}}); err == nil {
+		t.Error("AddPlayer(third_user) with a taken color: got no error, want error")
+	}
+}
+
+func TestAddPlayerWithMultipleColorsAfterMoves(t *testing.T) {
+	s := newDummyService(1234)
+	if err := s.AddPlayer(context.Background(), GameID(1234), "some_user", nil); err != nil {
+		t.Fatalf("AddPlayer(some_user): got err %v, want no error", err)
+	}
+	playOrDie(t, s.game, Move{Player: s.game.Players[0], PieceIndex: -1})
+	opts := &PlayerOptions{Colors: []Color{Yellow, Green}}
+	if err := s.AddPlayer(context.Background(), GameID(1234), "other_user", opts); err == nil || !strings.Contains(err.Error(), "moves were played") {
+		t.Errorf("AddPlayer(other_user) after a move: got err %v, want moves were played error", err)
+	}
+	if got, want := len(s.game.Players), 1; got != want {
+		t.Errorf("Num players after failed AddPlayer(): got %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,317 @@
+package blokus
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// Versions of the binary formats. Each encoding starts with its version byte.
+const (
+	positionVersion = 1
+	movesVersion    = 1
+
+	// Largest number of players in a binary position, since each cell stores its player in 2 bits.
+	maxBinaryPlayers = 4
+	// Bits of the first byte of an encoded move.
+	moveRotBits  = 0x03
+	moveFlipBit  = 0x04
+	movePassBit  = 0x08
+	moveFlagBits = 0x0F
+	// A resignation is a pass with the flip bit set, which passes never have.
+	moveResignBits = movePassBit | moveFlipBit
+	movePlayerAt   = 4
+)
+
+// MarshalPosition encodes the current position of the game in a compact binary format:
+// the board as one bit per cell for whether it's occupied, followed by the index of the occupying player
+// in 2 bits per occupied cell, and for each player the status and one bit per piece for whether it's placed.
+// A flat 2 bits per cell can't tell an empty cell from four colors, so the occupied bits come first;
+// on a typical board that's still close to 2 bits per cell.
+// The setup of the game, e.g. the pieces and player names, and the moves played are not included,
+// so the position can only be decoded into a game with the same setup. Games can have at most 4 colors,
+// e.g. not a game with both the classic and the Duo colors.
+func (g *Game) MarshalPosition() ([]byte, error) {
+	if len(g.Players) > maxBinaryPlayers {
+		return nil, fmt.Errorf("Binary positions can have at most %d colors, got %d", maxBinaryPlayers, len(g.Players))
+	}
+	seats := map[Color]int{}
+	for i, p := range g.Players {
+		seats[p.Color] = i
+	}
+
+	b := []byte{positionVersion}
+	b = binary.AppendUvarint(b, uint64(g.Board.Height))
+	b = binary.AppendUvarint(b, uint64(g.Board.Width))
+	b = binary.AppendUvarint(b, uint64(len(g.Players)))
+	b = binary.AppendUvarint(b, uint64(len(g.Pieces)))
+	b = binary.AppendUvarint(b, uint64(g.CurPlayerIndex))
+	for _, p := range g.Players {
+		b = append(b, byte(p.Status))
+		b = append(b, packBits(p.PlacedPieces)...)
+	}
+
+	numCells := g.Board.Height * g.Board.Width
+	occupied := make([]bool, numCells)
+	var seatBits []bool
+	for i := 0; i < numCells; i++ {
+		c := g.Board.Cell(Coord{i / g.Board.Width, i % g.Board.Width})
+		if !c.IsColored() {
+			continue
+		}
+		seat, ok := seats[c]
+		if !ok {
+			return nil, fmt.Errorf("No player with color %v on the board", c)
+		}
+		occupied[i] = true
+		seatBits = append(seatBits, seat&2 != 0, seat&1 != 0)
+	}
+	b = append(b, packBits(occupied)...)
+	b = append(b, packBits(seatBits)...)
+	return b, nil
+}
+
+// UnmarshalPosition sets the game to a position encoded by MarshalPosition. The game must have the same setup
+// as the encoded game. Moves and redos are cleared, since they're not part of the position.
+// If the data is invalid, the game is left unchanged.
+func (g *Game) UnmarshalPosition(data []byte) error {
+	r := &byteReader{data: data}
+	if v := r.byte(); r.err == nil && v != positionVersion {
+		return fmt.Errorf("Unsupported position version %d, want %d", v, positionVersion)
+	}
+	height, width := r.uvarint(), r.uvarint()
+	numPlayers, numPieces := r.uvarint(), r.uvarint()
+	cur := r.uvarint()
+	if r.err != nil {
+		return r.err
+	}
+	if height != uint64(g.Board.Height) || width != uint64(g.Board.Width) {
+		return fmt.Errorf("Position has board size %dx%d, want %dx%d", height, width, g.Board.Height, g.Board.Width)
+	}
+	if numPlayers != uint64(len(g.Players)) || numPieces != uint64(len(g.Pieces)) {
+		return fmt.Errorf("Position has %d players and %d pieces, want %d and %d", numPlayers, numPieces, len(g.Players), len(g.Pieces))
+	}
+	if len(g.Players) > 0 && cur >= numPlayers {
+		return fmt.Errorf("Current player index out of range: %d", cur)
+	}
+
+	statuses := make([]PlayerStatus, len(g.Players))
+	placed := make([][]bool, len(g.Players))
+	for i := range g.Players {
+		statuses[i] = PlayerStatus(r.byte())
+		if statuses[i] > StatusAllPlaced {
+			return fmt.Errorf("Player %d has invalid status: %d", i, statuses[i])
+		}
+		placed[i] = unpackBits(r.bytes(packedLen(len(g.Pieces))), len(g.Pieces))
+	}
+	numCells := g.Board.Height * g.Board.Width
+	occupied := unpackBits(r.bytes(packedLen(numCells)), numCells)
+	numOccupied := 0
+	for _, o := range occupied {
+		if o {
+			numOccupied++
+		}
+	}
+	seatBits := unpackBits(r.bytes(packedLen(2*numOccupied)), 2*numOccupied)
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.data) > 0 {
+		return fmt.Errorf("Position has %d extra bytes", len(r.data))
+	}
+
+	board, err := NewRectBoard(g.Board.Height, g.Board.Width)
+	if err != nil {
+		return err
+	}
+	board.initGrid()
+	j := 0
+	for i, o := range occupied {
+		if !o {
+			continue
+		}
+		seat := 0
+		if seatBits[2*j] {
+			seat += 2
+		}
+		if seatBits[2*j+1] {
+			seat++
+		}
+		j++
+		if seat >= len(g.Players) {
+			return fmt.Errorf("Cell %d has invalid player index: %d", i, seat)
+		}
+		board.SetCell(Coord{i / board.Width, i % board.Width}, g.Players[seat].Color)
+	}
+
+	g.Board = board
+	for i, p := range g.Players {
+		p.Status = statuses[i]
+		p.PlacedPieces = placed[i]
+	}
+	g.CurPlayerIndex = int(cur)
+	g.Moves = nil
+	g.Redos = nil
+	return nil
+}
+
+// MarshalMoves encodes the moves in a compact binary format, with players given by their index in the game.
+// Each move takes a byte for the player index, orientation and whether it's a pass or resignation,
+// followed by varints for the piece index and location unless it's a pass.
+func (g *Game) MarshalMoves(moves []Move) ([]byte, error) {
+	b := []byte{movesVersion}
+	b = binary.AppendUvarint(b, uint64(len(moves)))
+	for i, m := range moves {
+		seat := g.playerIndex(m.Player)
+		if seat < 0 || seat >= 1<<(8-movePlayerAt) {
+			return nil, fmt.Errorf("Player of move %d is not in the game", i)
+		}
+		head := byte(seat << movePlayerAt)
+		if m.Resign {
+			b = append(b, head|moveResignBits)
+			continue
+		}
+		if m.IsPass() {
+			b = append(b, head|movePassBit)
+			continue
+		}
+		head |= byte(Normalize(m.Orient.Rot))
+		if m.Orient.Flip {
+			head |= moveFlipBit
+		}
+		b = append(b, head)
+		b = binary.AppendUvarint(b, uint64(m.PieceIndex))
+		b = binary.AppendVarint(b, int64(m.Loc.X))
+		b = binary.AppendVarint(b, int64(m.Loc.Y))
+	}
+	return b, nil
+}
+
+// UnmarshalMoves decodes moves encoded by MarshalMoves with the same game setup.
+// The moves are not checked against the board.
+func (g *Game) UnmarshalMoves(data []byte) ([]Move, error) {
+	r := &byteReader{data: data}
+	if v := r.byte(); r.err == nil && v != movesVersion {
+		return nil, fmt.Errorf("Unsupported move list version %d, want %d", v, movesVersion)
+	}
+	n := r.uvarint()
+	if r.err != nil {
+		return nil, r.err
+	}
+	// Every move takes at least one byte, which also bounds the allocation below.
+	if n > uint64(len(r.data)) {
+		return nil, fmt.Errorf("Move list has %d moves, but only %d bytes", n, len(r.data))
+	}
+	moves := make([]Move, 0, n)
+	for i := uint64(0); i < n; i++ {
+		head := r.byte()
+		if r.err != nil {
+			return nil, r.err
+		}
+		seat := int(head >> movePlayerAt)
+		if seat >= len(g.Players) {
+			return nil, fmt.Errorf("Move %d has invalid player index: %d", i, seat)
+		}
+		m := Move{Player: g.Players[seat], PieceIndex: -1}
+		if head&movePassBit == 0 {
+			m.Orient = Orientation{Rot: Rotation(head & moveRotBits), Flip: head&moveFlipBit != 0}
+			piece := r.uvarint()
+			m.Loc = Coord{int(r.varint()), int(r.varint())}
+			if r.err != nil {
+				return nil, r.err
+			}
+			if piece >= uint64(len(g.Pieces)) {
+				return nil, fmt.Errorf("Move %d has piece index out of range: %d", i, piece)
+			}
+			m.PieceIndex = int(piece)
+		} else if head&moveFlagBits == moveResignBits {
+			m.Resign = true
+		} else if head&moveFlagBits != movePassBit {
+			return nil, fmt.Errorf("Move %d is a pass with an orientation", i)
+		}
+		moves = append(moves, m)
+	}
+	if len(r.data) > 0 {
+		return nil, fmt.Errorf("Move list has %d extra bytes", len(r.data))
+	}
+	return moves, nil
+}
+
+// byteReader reads the binary formats, remembering the first error.
+type byteReader struct {
+	data []byte
+	err  error
+}
+
+func (r *byteReader) byte() byte {
+	b := r.bytes(1)
+	if len(b) == 0 {
+		return 0
+	}
+	return b[0]
+}
+
+func (r *byteReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.data) {
+		r.err = fmt.Errorf("Unexpected end of data")
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
+
+func (r *byteReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = fmt.Errorf("Invalid varint")
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *byteReader) varint() int64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.err = fmt.Errorf("Invalid varint")
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func packedLen(numBits int) int {
+	return (numBits + 7) / 8
+}
+
+// packBits packs the bools into bytes, 8 per byte starting from the most significant bit.
+func packBits(bits []bool) []byte {
+	b := make([]byte, packedLen(len(bits)))
+	for i, set := range bits {
+		if set {
+			b[i/8] |= 0x80 >> uint(i%8)
+		}
+	}
+	return b
+}
+
+// unpackBits is the inverse of packBits. It returns nil if there are too few bytes.
+func unpackBits(b []byte, numBits int) []bool {
+	if len(b) < packedLen(numBits) {
+		return nil
+	}
+	bits := make([]bool, numBits)
+	for i := range bits {
+		bits[i] = b[i/8]&(0x80>>uint(i%8)) != 0
+	}
+	return bits
+}
@@ -0,0 +1,188 @@
+package blokus
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPositionRoundTrip(t *testing.T) {
+	g := newMidGame(t, 37)
+	g.Players[2].Status = StatusResigned
+	data, err := g.MarshalPosition()
+	if err != nil {
+		t.Fatalf("MarshalPosition(): got %v, want no error", err)
+	}
+	// 6 bytes of header, 4 players with a status byte and 3 bytes of pieces, 50 bytes of occupied cells,
+	// and 2 bits per occupied cell.
+	occupied := 0
+	for _, c := range g.Board.Grid {
+		if c.IsColored() {
+			occupied++
+		}
+	}
+	if got, want := len(data), 6+4*4+50+packedLen(2*occupied); got != want {
+		t.Errorf("MarshalPosition(): got %d bytes, want %d", got, want)
+	}
+
+	got := newMidGame(t, 0)
+	if err := got.UnmarshalPosition(data); err != nil {
+		t.Fatalf("UnmarshalPosition(): got %v, want no error", err)
+	}
+	if !reflect.DeepEqual(got.Board.Grid, g.Board.Grid) {
+		t.Error("UnmarshalPosition(): got different board, want same board")
+	}
+	for i, p := range got.Players {
+		if !reflect.DeepEqual(p.PlacedPieces, g.Players[i].PlacedPieces) || p.Status != g.Players[i].Status {
+			t.Errorf("Player %d: got %v %v, want %v %v", i, p.PlacedPieces, p.Status, g.Players[i].PlacedPieces, g.Players[i].Status)
+		}
+	}
+	if got, want := got.CurPlayerIndex, g.CurPlayerIndex; got != want {
+		t.Errorf("Current player index: got %v, want %v", got, want)
+	}
+	if got, want := len(got.LegalMoves(got.CurrentPlayer())), len(g.LegalMoves(g.CurrentPlayer())); got != want {
+		t.Errorf("LegalMoves() count after UnmarshalPosition(): got %v, want %v", got, want)
+	}
+}
+
+func TestUnmarshalPositionErrors(t *testing.T) {
+	g := newMidGame(t, 10)
+	data, err := g.MarshalPosition()
+	if err != nil {
+		t.Fatalf("MarshalPosition(): got %v, want no error", err)
+	}
+	duo, err := DuoVariant().NewGame(DefaultPieces(), []string{"foo", "bar"})
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	if err := duo.UnmarshalPosition(data); err == nil {
+		t.Error("UnmarshalPosition() into game with different setup: got no error, want error")
+	}
+
+	other := newMidGame(t, 0)
+	for _, bad := range [][]byte{
+		nil,
+		append([]byte{positionVersion + 1}, data[1:]...),
+		data[:len(data)-1],
+		append(append([]byte(nil), data...), 0),
+	} {
+		if err := other.UnmarshalPosition(bad); err == nil {
+			t.Errorf("UnmarshalPosition(%v): got no error, want error", bad)
+		}
+	}
+	if got := len(other.Moves); got != 0 {
+		t.Errorf("Moves after failed UnmarshalPosition(): got %d, want 0", got)
+	}
+}
+
+func TestMarshalPositionTooManyPlayers(t *testing.T) {
+	for _, n := range []int{5, 6} {
+		g, err := NewGame(DefaultBoardSize, DefaultPieces())
+		if err != nil {
+			t.Fatalf("NewGame(): got %v, want no error", err)
+		}
+		for c := Blue; c <= Color(n); c++ {
+			if err := g.AddPlayer(c.String(), c, Coord{0, int(c)}); err != nil {
+				t.Fatalf("AddPlayer(): got %v, want no error", err)
+			}
+		}
+		if _, err := g.MarshalPosition(); err == nil || !strings.Contains(err.Error(), "at most 4 colors") {
+			t.Errorf("MarshalPosition() with %d colors: got %v, want at most 4 colors error", n, err)
+		}
+	}
+}
+
+func TestMovesRoundTrip(t *testing.T) {
+	g := newMidGame(t, 60)
+	var moves []Move
+	for _, m := range g.Moves {
+		moves = append(moves, *m)
+	}
+	data, err := g.MarshalMoves(moves)
+	if err != nil {
+		t.Fatalf("MarshalMoves(): got %v, want no error", err)
+	}
+	got, err := g.UnmarshalMoves(data)
+	if err != nil {
+		t.Fatalf("UnmarshalMoves(): got %v, want no error", err)
+	}
+	if !reflect.DeepEqual(got, moves) {
+		t.Errorf("UnmarshalMoves(): got %v, want %v", got, moves)
+	}
+	if _, err := g.MarshalMoves([]Move{{Player: &Player{}}}); err == nil {
+		t.Error("MarshalMoves() with player not in game: got no error, want error")
+	}
+	for _, bad := range [][]byte{
+		nil,
+		{movesVersion + 1, 0},
+		{movesVersion, 1},
+		{movesVersion, 1, 0x40 | movePassBit},
+		{movesVersion, 1, movePassBit | 1},
+		{movesVersion, 1, 0, 21, 0, 0},
+		{movesVersion, 0, 0},
+	} {
+		if _, err := g.UnmarshalMoves(bad); err == nil {
+			t.Errorf("UnmarshalMoves(%v): got no error, want error", bad)
+		}
+	}
+}
+
+func FuzzUnmarshalPosition(f *testing.F) {
+	for _, turns := range []int{0, 5, 40} {
+		g := newMidGame(f, turns)
+		data, err := g.MarshalPosition()
+		if err != nil {
+			f.Fatalf("MarshalPosition(): got %v, want no error", err)
+		}
+		f.Add(data)
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g := newMidGame(t, 0)
+		if err := g.UnmarshalPosition(data); err != nil {
+			return
+		}
+		// Anything that decodes must encode to a position that decodes the same way.
+		again, err := g.MarshalPosition()
+		if err != nil {
+			t.Fatalf("MarshalPosition() after UnmarshalPosition(): got %v, want no error", err)
+		}
+		other := newMidGame(t, 0)
+		if err := other.UnmarshalPosition(again); err != nil {
+			t.Fatalf("UnmarshalPosition() of re-encoded position: got %v, want no error", err)
+		}
+		if !reflect.DeepEqual(other.Board.Grid, g.Board.Grid) {
+			t.Error("Re-encoded position: got different board, want same board")
+		}
+	})
+}
+
+func FuzzUnmarshalMoves(f *testing.F) {
+	g := newMidGame(f, 0)
+	played := newMidGame(f, 30)
+	var moves []Move
+	for _, m := range played.Moves {
+		moves = append(moves, Move{Player: g.Players[played.playerIndex(m.Player)], PieceIndex: m.PieceIndex, Orient: m.Orient, Loc: m.Loc})
+	}
+	data, err := g.MarshalMoves(moves)
+	if err != nil {
+		f.Fatalf("MarshalMoves(): got %v, want no error", err)
+	}
+	f.Add(data)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		moves, err := g.UnmarshalMoves(data)
+		if err != nil {
+			return
+		}
+		again, err := g.MarshalMoves(moves)
+		if err != nil {
+			t.Fatalf("MarshalMoves() after UnmarshalMoves(): got %v, want no error", err)
+		}
+		got, err := g.UnmarshalMoves(again)
+		if err != nil {
+			t.Fatalf("UnmarshalMoves() of re-encoded moves: got %v, want no error", err)
+		}
+		if !reflect.DeepEqual(got, moves) {
+			t.Errorf("Re-encoded moves: got %v, want %v", got, moves)
+		}
+	})
+}
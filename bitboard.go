@@ -0,0 +1,124 @@
+package blokus
+
+import (
+	"math/bits"
+)
+
+// bitset is a set of cell indexes, where a cell at (x,y) has index x*width+y.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (s bitset) has(i int) bool {
+	return s[i/64]&(1<<uint(i%64)) != 0
+}
+
+func (s bitset) set(i int) {
+	s[i/64] |= 1 << uint(i%64)
+}
+
+func (s bitset) clear(i int) {
+	s[i/64] &^= 1 << uint(i%64)
+}
+
+// forEach calls fn with every index in the set in increasing order.
+func (s bitset) forEach(fn func(i int)) {
+	for w, word := range s {
+		for word != 0 {
+			fn(w*64 + bits.TrailingZeros64(word))
+			word &= word - 1
+		}
+	}
+}
+
+// bitboard caches the cells of a board as bitsets, so placements can be checked without scanning neighbors.
+type bitboard struct {
+	height, width int
+	// Cells with any color.
+	occupied bitset
+	// Cells of each color.
+	colors [colorEnd]bitset
+	// Cells a piece of each color cannot cover, i.e. occupied cells and cells sharing an edge with the color.
+	forbidden [colorEnd]bitset
+	// Cells a piece of each color can cover to connect with the color, i.e. cells diagonally touching the color
+	// that are not forbidden.
+	anchors [colorEnd]bitset
+}
+
+func newBitboard(height, width int) *bitboard {
+	n := height * width
+	bb := &bitboard{
+		height:   height,
+		width:    width,
+		occupied: newBitset(n),
+	}
+	for c := range bb.colors {
+		bb.colors[c] = newBitset(n)
+		bb.forbidden[c] = newBitset(n)
+		bb.anchors[c] = newBitset(n)
+	}
+	return bb
+}
+
+func (bb *bitboard) index(c Coord) int {
+	return c.X*bb.width + c.Y
+}
+
+func (bb *bitboard) inBounds(c Coord) bool {
+	return c.X >= 0 && c.Y >= 0 && c.X < bb.height && c.Y < bb.width
+}
+
+// add marks an empty cell as having the color and updates the masks around it.
+// Masks only grow when cells are filled, so they can be built one cell at a time in any order.
+func (bb *bitboard) add(c Coord, color Color) {
+	i := bb.index(c)
+	bb.occupied.set(i)
+	bb.colors[color].set(i)
+	for k := range bb.forbidden {
+		bb.forbidden[k].set(i)
+		bb.anchors[k].clear(i)
+	}
+	for _, n := range neighbors {
+		n = Coord{c.X + n.X, c.Y + n.Y}
+		if bb.inBounds(n) {
+			bb.forbidden[color].set(bb.index(n))
+			bb.anchors[color].clear(bb.index(n))
+		}
+	}
+	for _, d := range diagonals {
+		d = Coord{c.X + d.X, c.Y + d.Y}
+		if bb.inBounds(d) && !bb.forbidden[color].has(bb.index(d)) {
+			bb.anchors[color].set(bb.index(d))
+		}
+	}
+}
+
+// bitboard returns the cached bitboard of the board, building it from the grid if needed.
+func (b *Board) bitboard() *bitboard {
+	if b.bits != nil {
+		return b.bits
+	}
+	bb := newBitboard(b.Height, b.Width)
+	for i, color := range b.Grid {
+		if color.IsColored() {
+			bb.add(Coord{i / b.Width, i % b.Width}, color)
+		}
+	}
+	b.bits = bb
+	return bb
+}
+
+// updateBitboard keeps the cached bitboard in sync after a cell changed from one color to another.
+func (b *Board) updateBitboard(c Coord, old, color Color) {
+	if b.bits == nil || old == color {
+		return
+	}
+	if old.IsColored() || !color.IsColored() {
+		// Masks can't shrink one cell at a time, so rebuild when needed.
+		b.bits = nil
+		return
+	}
+	b.bits.add(c, color)
+}
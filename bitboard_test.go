@@ -0,0 +1,88 @@
+package blokus
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestBitset(t *testing.T) {
+	s := newBitset(130)
+	for _, i := range []int{0, 63, 64, 129} {
+		s.set(i)
+	}
+	s.clear(63)
+	var got []int
+	s.forEach(func(i int) {
+		got = append(got, i)
+	})
+	want := []int{0, 64, 129}
+	if len(got) != len(want) {
+		t.Fatalf("forEach(): got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("forEach(): got %v, want %v", got, want)
+		}
+		if !s.has(want[i]) {
+			t.Errorf("has(%v): got false, want true", want[i])
+		}
+	}
+	if s.has(63) {
+		t.Error("has(63) after clear: got true, want false")
+	}
+}
+
+// checkBitboard compares the cached bitboard with masks calculated by scanning the grid.
+func checkBitboard(t *testing.T, b *Board) {
+	bb := b.bitboard()
+	for x := 0; x < b.Height; x++ {
+		for y := 0; y < b.Width; y++ {
+			c := Coord{x, y}
+			i := bb.index(c)
+			if got, want := bb.occupied.has(i), b.Cell(c).IsColored(); got != want {
+				t.Errorf("Occupied %v: got %v, want %v", c, got, want)
+			}
+			for color := Color(1); color < colorEnd; color++ {
+				edge, corner := false, false
+				for _, n := range neighbors {
+					edge = edge || b.Cell(Coord{x + n.X, y + n.Y}) == color
+				}
+				for _, d := range diagonals {
+					corner = corner || b.Cell(Coord{x + d.X, y + d.Y}) == color
+				}
+				forbidden := b.Cell(c).IsColored() || edge
+				if got := bb.colors[color].has(i); got != (b.Cell(c) == color) {
+					t.Errorf("Color %v at %v: got %v, want %v", color, c, got, !got)
+				}
+				if got := bb.forbidden[color].has(i); got != forbidden {
+					t.Errorf("Forbidden for %v at %v: got %v, want %v", color, c, got, forbidden)
+				}
+				if got, want := bb.anchors[color].has(i), !forbidden && corner; got != want {
+					t.Errorf("Anchor for %v at %v: got %v, want %v", color, c, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestBitboardInSyncWithGrid(t *testing.T) {
+	b, err := NewBoard(8)
+	if err != nil {
+		t.Fatalf("NewBoard(): got error %v, want no error", err)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 200; i++ {
+		c := Coord{r.Intn(b.Height), r.Intn(b.Width)}
+		// Mostly fill cells, sometimes clear or overwrite them.
+		color := Color(r.Intn(int(colorEnd)))
+		b.SetCell(c, color)
+		if i%20 == 0 {
+			checkBitboard(t, b)
+		}
+	}
+	checkBitboard(t, b)
+
+	// A board with a grid but no bitboard yet, e.g. loaded from storage, builds it from the grid.
+	loaded := &Board{Height: b.Height, Width: b.Width, Grid: append([]Color(nil), b.Grid...)}
+	checkBitboard(t, loaded)
+}
@@ -0,0 +1,58 @@
+// Package bot has computer players for blokus games.
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hueich/blokus"
+)
+
+// Bot chooses moves for a player of a game.
+type Bot interface {
+	// Move returns the move to play for the player at the seat, which is the index of the player in the game.
+	// The move is a pass if the player has no legal move. The snapshot must not be changed.
+	Move(ctx context.Context, s *blokus.Snapshot, seat int) (blokus.Move, error)
+}
+
+// Lookup returns a new bot of the kind with the name, e.g. "random", "greedy", "mcts", "maxn" or "paranoid".
+func Lookup(name string) (Bot, error) {
+	switch strings.ToLower(name) {
+	case "random":
+		return NewRandom(0), nil
+	case "greedy":
+		return NewGreedy(), nil
+	case "mcts":
+		return NewMCTS(), nil
+	case "maxn":
+		return NewSearch(MaxN), nil
+	case "paranoid":
+		return NewSearch(Paranoid), nil
+	}
+	return nil, fmt.Errorf("Unknown bot: %v", name)
+}
+
+// Play asks the bot for the move of the current player, and plays it in the game.
+func Play(ctx context.Context, g *blokus.Game, b Bot) (blokus.TurnResult, error) {
+	if len(g.Players) == 0 {
+		return blokus.TurnResult{}, fmt.Errorf("Game has no players")
+	}
+	seat := g.CurPlayerIndex
+	m, err := b.Move(ctx, g.Snapshot(), seat)
+	if err != nil {
+		return blokus.TurnResult{}, err
+	}
+	// The bot's move points to a copy of the player.
+	m.Player = g.Players[seat]
+	return g.Play(m)
+}
+
+// pass returns a move that passes the turn of the player at the seat.
+func pass(s *blokus.Snapshot, seat int) (blokus.Move, error) {
+	players := s.Players()
+	if seat < 0 || seat >= len(players) {
+		return blokus.Move{}, fmt.Errorf("Seat out of range: %d", seat)
+	}
+	return blokus.Move{Player: players[seat], PieceIndex: -1}, nil
+}
@@ -0,0 +1,113 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hueich/blokus"
+)
+
+func newGameOrDie(t *testing.T, v *blokus.Variant) *blokus.Game {
+	names := []string{"foo", "bar", "baz", "qux"}[:v.NumPlayers()]
+	g, err := v.NewGame(blokus.DefaultPieces(), names)
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	return g
+}
+
+// playToEnd plays the game with the bots taking turns by seat, and returns the number of turns.
+func playToEnd(t *testing.T, g *blokus.Game, bots []Bot) int {
+	turns := 0
+	for !g.IsGameEnd() {
+		if _, err := Play(context.Background(), g, bots[g.CurPlayerIndex%len(bots)]); err != nil {
+			t.Fatalf("Play() at turn %d: got %v, want no error", turns, err)
+		}
+		turns++
+	}
+	return turns
+}
+
+// fillBoard covers the board in the color, so that the other players have no legal move.
+func fillBoard(g *blokus.Game, color blokus.Color) {
+	for x := 0; x < g.Board.Height; x++ {
+		for y := 0; y < g.Board.Width; y++ {
+			g.Board.SetCell(blokus.Coord{X: x, Y: y}, color)
+		}
+	}
+}
+
+func TestPlayToEnd(t *testing.T) {
+	g := newGameOrDie(t, blokus.ClassicVariant())
+	playToEnd(t, g, []Bot{NewRandom(1), NewGreedy()})
+	if err := g.Verify(); err != nil {
+		t.Errorf("Verify(): got %v, want no error", err)
+	}
+	if _, err := g.Winners(); err != nil {
+		t.Errorf("Winners(): got %v, want no error", err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	for _, name := range []string{"random", "Greedy", "mcts", "maxn", "paranoid"} {
+		if _, err := Lookup(name); err != nil {
+			t.Errorf("Lookup(%v): got %v, want no error", name, err)
+		}
+	}
+	if _, err := Lookup("genius"); err == nil {
+		t.Error("Lookup(genius): got no error, want error")
+	}
+}
+
+func TestPlayWithNoPlayers(t *testing.T) {
+	g, err := blokus.NewGame(blokus.DefaultBoardSize, blokus.DefaultPieces())
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	if _, err := Play(context.Background(), g, NewGreedy()); err == nil {
+		t.Error("Play() with no players: got no error, want error")
+	}
+}
+
+func TestBots(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		bot  Bot
+		// Unlimited is the bot with no limit on its search, which it should refuse. Nil if it doesn't search.
+		unlimited Bot
+		// CheckTurn is whether the bot refuses to move for a seat whose turn it isn't.
+		checkTurn bool
+	}{
+		{"random", NewRandom(1), nil, false},
+		{"greedy", NewGreedy(), nil, false},
+		{"mcts", newTestMCTS(10, 1), newTestMCTS(0, 1), true},
+		{"maxn", newTestSearch(MaxN, 2), newTestSearch(MaxN, 0), true},
+		{"paranoid", newTestSearch(Paranoid, 2), newTestSearch(Paranoid, 0), true},
+	} {
+		g := newGameOrDie(t, blokus.DuoVariant())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := tc.bot.Move(ctx, g.Snapshot(), 0); err == nil {
+			t.Errorf("Move() of %v with canceled context: got no error, want error", tc.name)
+		}
+		if tc.unlimited != nil {
+			if _, err := tc.unlimited.Move(context.Background(), g.Snapshot(), 0); err == nil {
+				t.Errorf("Move() of %v without limits: got no error, want error", tc.name)
+			}
+		}
+		if tc.checkTurn {
+			if _, err := tc.bot.Move(context.Background(), g.Snapshot(), 1); err == nil {
+				t.Errorf("Move() of %v out of turn: got no error, want error", tc.name)
+			}
+		}
+
+		fillBoard(g, g.Players[1].Color)
+		m, err := tc.bot.Move(context.Background(), g.Snapshot(), 0)
+		if err != nil {
+			t.Fatalf("Move() of %v: got %v, want no error", tc.name, err)
+		}
+		if !m.IsPass() {
+			t.Errorf("Move() of %v with no legal moves: got %v, want pass", tc.name, m)
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hueich/blokus"
+)
+
+// Engine is a bot running in another process, which speaks the engine protocol on its stdin and stdout.
+// The process is started on the first move, and kept running for the next ones until Close.
+// An engine plays one move at a time.
+type Engine struct {
+	// Path and Args of the command to run, as in exec.Command.
+	Path string
+	Args []string
+	// Env is the environment of the process. If nil, the process gets the environment of this one.
+	Env []string
+	// Stderr receives the standard error of the process. If nil, it's discarded.
+	Stderr io.Writer
+	// Duration is how long the engine may search per move. Zero means as long as the context allows.
+	// An engine still searching StopTimeout after that is asked to stop, and killed if it doesn't.
+	Duration time.Duration
+	// StopTimeout is how long to wait for the move after asking the engine to stop,
+	// before the process is killed.
+	StopTimeout time.Duration
+
+	mu sync.Mutex
+	// Set while the process is running. Done is closed when the process is stopped.
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	lines chan string
+	done  chan struct{}
+	name  string
+	// Tag lines of the setup last sent, to only send them again for another game.
+	setup []string
+}
+
+// NewEngine creates a bot that runs the command with the arguments as an engine.
+func NewEngine(path string, args ...string) *Engine {
+	return &Engine{
+		Path:        path,
+		Args:        args,
+		Duration:    time.Second,
+		StopTimeout: time.Second,
+	}
+}
+
+// Name returns the name the engine gave itself, or the path of the command if it's not running.
+func (e *Engine) Name() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.name) > 0 {
+		return e.name
+	}
+	return e.Path
+}
+
+func (e *Engine) Move(ctx context.Context, s *blokus.Snapshot, seat int) (blokus.Move, error) {
+	g := s.Game()
+	if seat < 0 || seat >= len(g.Players) {
+		return blokus.Move{}, fmt.Errorf("Seat out of range: %d", seat)
+	}
+	if g.CurPlayerIndex != seat {
+		return blokus.Move{}, fmt.Errorf("It's not the turn of seat %d", seat)
+	}
+	if err := ctx.Err(); err != nil {
+		return blokus.Move{}, err
+	}
+	setup, err := setupLines(g)
+	if err != nil {
+		return blokus.Move{}, err
+	}
+	goCmd := cmdGo
+	if d, ok := e.timeLimit(ctx); ok {
+		goCmd = fmt.Sprintf("%s time %d", cmdGo, d.Milliseconds())
+	}
+	if e.Duration > 0 {
+		// Don't rely on the engine keeping to the time it was given.
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Duration+e.StopTimeout)
+		defer cancel()
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cmd == nil {
+		if err := e.start(ctx); err != nil {
+			return blokus.Move{}, err
+		}
+	}
+	if !equalLines(setup, e.setup) {
+		e.setup = nil
+		cmds := append([]string{cmdNewGame}, setup...)
+		for i := range setup {
+			cmds[i+1] = cmdSetup + " " + setup[i]
+		}
+		if err := e.send(cmds...); err != nil {
+			return blokus.Move{}, err
+		}
+		e.setup = setup
+	}
+	// Wait for the engine to take the position first, so that errors in the setup or position
+	// are all read before the search.
+	if err := e.send(positionLine(g), cmdIsReady); err != nil {
+		return blokus.Move{}, err
+	}
+	var setupErr error
+	for {
+		_, err := e.wait(ctx, replyReadyOK)
+		if err == nil {
+			break
+		}
+		if _, ok := err.(engineError); !ok {
+			return blokus.Move{}, err
+		}
+		if setupErr == nil {
+			setupErr = err
+		}
+	}
+	if setupErr != nil {
+		// Send the setup again next time, in case the engine didn't take it.
+		e.setup = nil
+		return blokus.Move{}, setupErr
+	}
+	if err := e.send(goCmd); err != nil {
+		return blokus.Move{}, err
+	}
+	reply, err := e.wait(ctx, replyBestMove)
+	if err != nil {
+		return blokus.Move{}, err
+	}
+	m, err := g.ParseMove(reply)
+	if err != nil {
+		return blokus.Move{}, fmt.Errorf("Engine played an invalid move: %v", err)
+	}
+	if m.Player != g.Players[seat] {
+		return blokus.Move{}, fmt.Errorf("Engine played for %v instead of %v: %q", m.Player.Name, g.Players[seat].Name, reply)
+	}
+	return m, nil
+}
+
+// timeLimit returns how long the engine may search, if limited by Duration or the context's deadline.
+func (e *Engine) timeLimit(ctx context.Context) (time.Duration, bool) {
+	d, ok := e.Duration, e.Duration > 0
+	if deadline, has := ctx.Deadline(); has {
+		if left := time.Until(deadline); !ok || left < d {
+			d, ok = left, true
+		}
+	}
+	if ok && d < time.Millisecond {
+		d = time.Millisecond
+	}
+	return d, ok
+}
+
+// start starts the process, and waits for it to be ready.
+func (e *Engine) start(ctx context.Context) error {
+	cmd := exec.Command(e.Path, e.Args...)
+	cmd.Env = e.Env
+	cmd.Stderr = e.Stderr
+	// Children of the engine may keep its output open after it exits, so don't wait for them.
+	cmd.WaitDelay = e.StopTimeout
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("Could not start engine: %v", err)
+	}
+	lines := make(chan string)
+	done := make(chan struct{})
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-done:
+				return
+			}
+		}
+	}()
+	e.cmd, e.stdin, e.lines, e.done, e.name, e.setup = cmd, stdin, lines, done, "", nil
+
+	if err := e.send(cmdBlokus); err != nil {
+		return err
+	}
+	if _, err := e.wait(ctx, replyBlokusOK); err != nil {
+		return err
+	}
+	return nil
+}
+
+// send writes the commands to the engine.
+func (e *Engine) send(cmds ...string) error {
+	if _, err := io.WriteString(e.stdin, strings.Join(cmds, "\n")+"\n"); err != nil {
+		e.kill()
+		return fmt.Errorf("Could not send to engine: %v", err)
+	}
+	return nil
+}
+
+// wait reads lines from the engine until the reply, and returns the rest of its line.
+// If the context is done first, the engine is asked to stop, and killed if it doesn't reply in time.
+func (e *Engine) wait(ctx context.Context, reply string) (string, error) {
+	done := ctx.Done()
+	var timeout <-chan time.Time
+	for {
+		select {
+		case line, ok := <-e.lines:
+			if !ok {
+				e.kill()
+				return "", fmt.Errorf("Engine exited")
+			}
+			cmd, args := splitCommand(line)
+			switch cmd {
+			case reply:
+				return args, nil
+			case replyError:
+				return "", engineError(args)
+			case replyID:
+				if name, ok := strings.CutPrefix(args, "name "); ok {
+					e.name = strings.TrimSpace(name)
+				}
+			}
+		case <-done:
+			if reply != replyBestMove {
+				e.kill()
+				return "", ctx.Err()
+			}
+			done = nil
+			if err := e.send(cmdStop); err != nil {
+				return "", err
+			}
+			timer := time.NewTimer(e.StopTimeout)
+			defer timer.Stop()
+			timeout = timer.C
+		case <-timeout:
+			e.kill()
+			return "", ctx.Err()
+		}
+	}
+}
+
+// Close asks the engine to quit, and waits for the process to exit. The engine is started again by the next move.
+func (e *Engine) Close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cmd == nil {
+		return nil
+	}
+	io.WriteString(e.stdin, cmdQuit+"\n")
+	e.stdin.Close()
+	// Read the rest of the output until the process exits, so it isn't stuck writing it.
+	lines, drained := e.lines, make(chan struct{})
+	go func() {
+		for range lines {
+		}
+		close(drained)
+	}()
+	var err error
+	select {
+	case <-drained:
+		err = e.cmd.Wait()
+	case <-time.After(e.StopTimeout):
+		e.cmd.Process.Kill()
+		// Waiting closes the output, which the engine's children may still have open.
+		err = e.cmd.Wait()
+		<-drained
+	}
+	close(e.done)
+	e.cmd, e.stdin, e.lines, e.done = nil, nil, nil, nil
+	return err
+}
+
+// kill ends the process after it misbehaved.
+func (e *Engine) kill() {
+	if e.cmd == nil {
+		return
+	}
+	e.stdin.Close()
+	e.cmd.Process.Kill()
+	close(e.done)
+	// Waiting closes the output, so the lines end even if the engine's children still have it open.
+	e.cmd.Wait()
+	for range e.lines {
+	}
+	e.cmd, e.stdin, e.lines, e.done = nil, nil, nil, nil
+}
+
+// engineError is an error reported by the engine.
+type engineError string
+
+func (e engineError) Error() string {
+	return "Engine error: " + string(e)
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
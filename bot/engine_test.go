@@ -0,0 +1,204 @@
+package bot
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hueich/blokus"
+)
+
+// stubEnv names the stub engine that the test binary runs as, when it's set in its environment.
+const stubEnv = "BLOKUS_STUB_ENGINE"
+
+func TestMain(m *testing.M) {
+	if stub := os.Getenv(stubEnv); len(stub) > 0 {
+		runStubEngine(stub)
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runStubEngine runs an engine on stdin and stdout. A "greedy" or "waiting" stub serves the bot,
+// while the others misbehave when asked to search: "garbage" plays nonsense, "hang" never plays,
+// "wrapper" never plays and has a child with its output, and "exit" exits.
+func runStubEngine(stub string) {
+	switch stub {
+	case "greedy":
+		Serve(context.Background(), NewGreedy(), "stub", os.Stdin, os.Stdout)
+		return
+	case "waiting":
+		Serve(context.Background(), waitingBot{}, "stub", os.Stdin, os.Stdout)
+		return
+	case "sleep":
+		time.Sleep(20 * time.Second)
+		return
+	case "wrapper":
+		// Like a script running the engine, the child keeps the output open when this is killed.
+		child := exec.Command(os.Args[0])
+		child.Env = append(os.Environ(), stubEnv+"=sleep")
+		child.Stdout = os.Stdout
+		if err := child.Start(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return
+		}
+	}
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		switch cmd, _ := splitCommand(scanner.Text()); cmd {
+		case cmdBlokus:
+			fmt.Println(replyBlokusOK)
+		case cmdIsReady:
+			fmt.Println(replyReadyOK)
+		case cmdGo:
+			switch stub {
+			case "garbage":
+				fmt.Println("bestmove somewhere nice")
+			case "exit":
+				return
+			}
+		}
+	}
+}
+
+// newStubEngine returns an engine that runs the test binary as the stub.
+func newStubEngine(t *testing.T, stub string) *Engine {
+	e := NewEngine(os.Args[0])
+	// With the race detector, processes wait a second before exiting unless told otherwise.
+	e.Env = append(os.Environ(), stubEnv+"="+stub, "GORACE=atexit_sleep_ms=0")
+	e.Stderr = os.Stderr
+	t.Cleanup(func() {
+		e.Close()
+	})
+	return e
+}
+
+func TestEngine(t *testing.T) {
+	// The stub engine plays like the greedy bot, so the games should be the same.
+	want := newGameOrDie(t, blokus.DuoVariant())
+	playToEnd(t, want, []Bot{NewGreedy()})
+	got := newGameOrDie(t, blokus.DuoVariant())
+	e := newStubEngine(t, "greedy")
+	playToEnd(t, got, []Bot{e, NewGreedy()})
+
+	if len(got.Moves) != len(want.Moves) {
+		t.Fatalf("Number of moves: got %d, want %d", len(got.Moves), len(want.Moves))
+	}
+	for i := range got.Moves {
+		if g, w := got.FormatMove(*got.Moves[i]), want.FormatMove(*want.Moves[i]); g != w {
+			t.Errorf("Move %d: got %v, want %v", i, g, w)
+		}
+	}
+	if got, want := e.Name(), "stub"; got != want {
+		t.Errorf("Name(): got %v, want %v", got, want)
+	}
+
+	// The engine is started again after closing.
+	if err := e.Close(); err != nil {
+		t.Errorf("Close(): got %v, want no error", err)
+	}
+	g := newGameOrDie(t, blokus.ClassicVariant())
+	if _, err := Play(context.Background(), g, e); err != nil {
+		t.Errorf("Play() after Close(): got %v, want no error", err)
+	}
+}
+
+func TestEngineStop(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	e := newStubEngine(t, "waiting")
+	e.Duration = 0
+	// The engine only answers when asked to stop at the deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := Play(ctx, g, e); err != nil {
+		t.Errorf("Play(): got %v, want no error", err)
+	}
+}
+
+func TestEngineErrors(t *testing.T) {
+	for _, tc := range []struct {
+		stub string
+		want string
+	}{
+		{"garbage", "invalid move"},
+		{"hang", "deadline exceeded"},
+		{"exit", "exited"},
+		{"missing", "start"},
+	} {
+		g := newGameOrDie(t, blokus.DuoVariant())
+		e := newStubEngine(t, tc.stub)
+		if tc.stub == "missing" {
+			e.Path = os.Args[0] + ".missing"
+		}
+		e.StopTimeout = 100 * time.Millisecond
+		// Only the hanging engine should run out of time.
+		timeout := 10 * time.Second
+		if tc.stub == "hang" {
+			timeout = time.Second
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		_, err := e.Move(ctx, g.Snapshot(), 0)
+		cancel()
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("Move() of %v engine: got %v, want error containing %q", tc.stub, err, tc.want)
+		}
+	}
+}
+
+func TestEngineDuration(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	e := newStubEngine(t, "hang")
+	e.Duration = 100 * time.Millisecond
+	e.StopTimeout = 100 * time.Millisecond
+	// The engine is stopped and killed without a deadline on the context.
+	done := make(chan error, 1)
+	go func() {
+		_, err := e.Move(context.Background(), g.Snapshot(), 0)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "deadline exceeded") {
+			t.Errorf("Move() of hanging engine: got %v, want deadline exceeded error", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Move() of hanging engine: got no answer, want deadline exceeded error")
+	}
+}
+
+func TestEngineKillWrapper(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	e := newStubEngine(t, "wrapper")
+	e.Stderr = io.Discard
+	e.Duration = 100 * time.Millisecond
+	e.StopTimeout = 100 * time.Millisecond
+	start := time.Now()
+	if _, err := e.Move(context.Background(), g.Snapshot(), 0); err == nil {
+		t.Error("Move() of hanging wrapper engine: got no error, want error")
+	}
+	if got, max := time.Since(start), 5*time.Second; got > max {
+		t.Errorf("Move() of hanging wrapper engine: took %v, want at most %v", got, max)
+	}
+}
+
+func TestEngineTimeLimit(t *testing.T) {
+	e := NewEngine("engine")
+	if d, ok := e.timeLimit(context.Background()); !ok || d != time.Second {
+		t.Errorf("timeLimit(): got %v, %v, want %v", d, ok, time.Second)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if d, ok := e.timeLimit(ctx); !ok || d > 100*time.Millisecond {
+		t.Errorf("timeLimit() with deadline: got %v, %v, want at most %v", d, ok, 100*time.Millisecond)
+	}
+	e.Duration = 0
+	if _, ok := e.timeLimit(context.Background()); ok {
+		t.Error("timeLimit() without limits: got a limit, want none")
+	}
+}
@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"github.com/hueich/blokus"
+)
+
+// Evaluator judges positions for search bots.
+type Evaluator interface {
+	// Evaluate returns the value of the position for each player of the game, in player order.
+	// Higher values are better for the player. The game must not be changed.
+	Evaluate(g *blokus.Game) []float64
+}
+
+// Evaluation is an Evaluator that adds up weighted features of each player's position.
+// Features that are only useful while the game goes on count nothing for inactive players and ended games.
+type Evaluation struct {
+	// ScoreWeight is the weight of the player's score, i.e. minus the squares left to place plus any bonus.
+	ScoreWeight float64
+	// AnchorWeight is the weight of each corner anchor where one of the player's remaining pieces fits.
+	AnchorWeight float64
+	// MobilityWeight is the weight of each legal move of the player.
+	MobilityWeight float64
+	// TerritoryWeight is the weight of each empty cell the player can reach in fewer steps than anyone else.
+	TerritoryWeight float64
+}
+
+// NewEvaluation creates an evaluation that values squares placed most, and room to play after that.
+func NewEvaluation() *Evaluation {
+	return &Evaluation{
+		ScoreWeight:     1,
+		AnchorWeight:    0.5,
+		MobilityWeight:  0.005,
+		TerritoryWeight: 0.1,
+	}
+}
+
+func (e *Evaluation) Evaluate(g *blokus.Game) []float64 {
+	values := make([]float64, len(g.Players))
+	ended := g.IsGameEnd()
+	for i, p := range g.Players {
+		if score, err := g.Score(p); err == nil {
+			values[i] += e.ScoreWeight * float64(score.Total)
+		}
+		if ended || !p.Status.IsActive() || (e.AnchorWeight == 0 && e.MobilityWeight == 0) {
+			continue
+		}
+		moves := g.LegalMoves(p)
+		values[i] += e.MobilityWeight*float64(len(moves)) + e.AnchorWeight*float64(reachableAnchors(g, p, moves))
+	}
+	if !ended && e.TerritoryWeight != 0 {
+		for i, n := range territory(g) {
+			values[i] += e.TerritoryWeight * float64(n)
+		}
+	}
+	return values
+}
+
+// reachableAnchors returns the number of the player's anchors covered by any of the moves.
+func reachableAnchors(g *blokus.Game, p *blokus.Player, moves []blokus.Move) int {
+	anchors := map[blokus.Coord]bool{}
+	for _, a := range playerAnchors(g.Board, p) {
+		anchors[a] = false
+	}
+	n := 0
+	for _, m := range moves {
+		for _, c := range moveCells(g.Pieces[m.PieceIndex], m) {
+			if covered, ok := anchors[c]; ok && !covered {
+				anchors[c] = true
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// territory returns the number of empty cells each player reaches first, in player order.
+// Players spread from their anchors one edge at a time, through empty cells that don't share an edge
+// with their own color. Cells reached first by several players at once belong to no one.
+// Inactive players don't spread.
+func territory(g *blokus.Game) []int {
+	b := g.Board
+	counts := make([]int, len(g.Players))
+	numCells := b.Height * b.Width
+	// Step at which each cell was first reached, and by which player, or -2 if by several.
+	steps := make([]int, numCells)
+	owners := make([]int, numCells)
+	for i := range steps {
+		steps[i] = -1
+	}
+	for i, p := range g.Players {
+		if !p.Status.IsActive() {
+			continue
+		}
+		for c, step := range distances(b, p) {
+			j := c.X*b.Width + c.Y
+			switch {
+			case steps[j] < 0 || step < steps[j]:
+				steps[j], owners[j] = step, i
+			case step == steps[j]:
+				owners[j] = -2
+			}
+		}
+	}
+	for j, step := range steps {
+		if step >= 0 && owners[j] >= 0 {
+			counts[owners[j]]++
+		}
+	}
+	return counts
+}
+
+// playerAnchors returns the cells where the player's next piece can connect: the corner anchors of the color,
+// and the starting position if it's still free, as in the move generator.
+func playerAnchors(b *blokus.Board, p *blokus.Player) []blokus.Coord {
+	anchors := b.CornerAnchors(p.Color)
+	if isFree(b, p.Color, p.StartPos) {
+		for _, a := range anchors {
+			if a == p.StartPos {
+				return anchors
+			}
+		}
+		anchors = append(anchors, p.StartPos)
+	}
+	return anchors
+}
+
+// isFree returns whether a piece of the color can cover the cell, i.e. it's empty and doesn't share an edge
+// with the color.
+func isFree(b *blokus.Board, color blokus.Color, c blokus.Coord) bool {
+	if b.IsOutOfBounds(c) || b.Cell(c).IsColored() {
+		return false
+	}
+	for _, e := range edges {
+		n := blokus.Coord{X: c.X + e.X, Y: c.Y + e.Y}
+		if !b.IsOutOfBounds(n) && b.Cell(n) == color {
+			return false
+		}
+	}
+	return true
+}
+
+// distances returns the number of steps to each empty cell the player can spread to from the player's anchors.
+func distances(b *blokus.Board, p *blokus.Player) map[blokus.Coord]int {
+	dist := map[blokus.Coord]int{}
+	queue := playerAnchors(b, p)
+	for _, a := range queue {
+		dist[a] = 0
+	}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		for _, e := range edges {
+			n := blokus.Coord{X: c.X + e.X, Y: c.Y + e.Y}
+			if _, ok := dist[n]; ok || !isFree(b, p.Color, n) {
+				continue
+			}
+			dist[n] = dist[c] + 1
+			queue = append(queue, n)
+		}
+	}
+	return dist
+}
@@ -0,0 +1,88 @@
+package bot
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hueich/blokus"
+)
+
+// newSmallGame creates a game on a 5x5 board with blue and yellow starting in opposite corners.
+func newSmallGame(t *testing.T) *blokus.Game {
+	g, err := blokus.NewGame(5, blokus.DefaultPieces())
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	if err := g.AddPlayer("foo", blokus.Blue, blokus.Coord{X: 0, Y: 0}); err != nil {
+		t.Fatalf("AddPlayer(): got %v, want no error", err)
+	}
+	if err := g.AddPlayer("bar", blokus.Yellow, blokus.Coord{X: 4, Y: 4}); err != nil {
+		t.Fatalf("AddPlayer(): got %v, want no error", err)
+	}
+	return g
+}
+
+func TestEvaluationIsSymmetric(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	values := NewEvaluation().Evaluate(g)
+	if got, want := values[0], values[1]; got != want {
+		t.Errorf("Values of the starting position: got %v and %v, want equal", got, want)
+	}
+}
+
+func TestEvaluationAtGameEnd(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	playToEnd(t, g, []Bot{NewGreedy()})
+	values := NewEvaluation().Evaluate(g)
+	for i, p := range g.Players {
+		score, err := g.Score(p)
+		if err != nil {
+			t.Fatalf("Score(): got %v, want no error", err)
+		}
+		if got, want := values[i], float64(score.Total); got != want {
+			t.Errorf("Value of %v at the end: got %v, want %v", p.Name, got, want)
+		}
+	}
+}
+
+func TestEvaluationWeights(t *testing.T) {
+	g := newSmallGame(t)
+	e := &Evaluation{TerritoryWeight: 1}
+	if got, want := e.Evaluate(g), []float64{10, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Evaluate() with territory only: got %v, want %v", got, want)
+	}
+	e = &Evaluation{AnchorWeight: 2}
+	if got, want := e.Evaluate(g), []float64{2, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Evaluate() with anchors only: got %v, want %v", got, want)
+	}
+}
+
+func TestTerritory(t *testing.T) {
+	g := newSmallGame(t)
+	// Cells on the diagonal between the corners are as far from both.
+	if got, want := territory(g), []int{10, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("territory(): got %v, want %v", got, want)
+	}
+	// The I3 down the left edge leaves blue to spread from (3,1), around the cells next to the I3.
+	if _, err := g.Play(blokus.Move{Player: g.Players[0], PieceIndex: 2, Loc: blokus.Coord{X: 0, Y: 0}}); err != nil {
+		t.Fatalf("Play(): got %v, want no error", err)
+	}
+	// Blue reaches the bottom left and the middle column first, and ties with yellow on (2,3), (3,3) and (4,2).
+	if got, want := territory(g), []int{7, 10}; !reflect.DeepEqual(got, want) {
+		t.Errorf("territory() after I3: got %v, want %v", got, want)
+	}
+}
+
+func TestReachableAnchors(t *testing.T) {
+	g := newSmallGame(t)
+	if _, err := g.Play(blokus.Move{Player: g.Players[0], PieceIndex: 2, Loc: blokus.Coord{X: 0, Y: 0}}); err != nil {
+		t.Fatalf("Play(): got %v, want no error", err)
+	}
+	p := g.Players[0]
+	if got, want := reachableAnchors(g, p, g.LegalMoves(p)), 1; got != want {
+		t.Errorf("reachableAnchors(): got %d, want %d", got, want)
+	}
+	if got, want := reachableAnchors(g, p, nil), 0; got != want {
+		t.Errorf("reachableAnchors() with no moves: got %d, want %d", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"context"
+
+	"github.com/hueich/blokus"
+)
+
+// Greedy plays the move with the best immediate score, preferring large pieces,
+// and then moves that open up the most new corner anchors for further pieces.
+type Greedy struct {
+	// SizeWeight is the score of each square of the placed piece.
+	SizeWeight int
+	// AnchorWeight is the score of each new corner anchor of the player's color.
+	AnchorWeight int
+}
+
+// NewGreedy creates a greedy bot that always plays the largest piece it can.
+func NewGreedy() *Greedy {
+	return &Greedy{SizeWeight: 100, AnchorWeight: 1}
+}
+
+func (b *Greedy) Move(ctx context.Context, s *blokus.Snapshot, seat int) (blokus.Move, error) {
+	moves := s.LegalMoves(seat)
+	if len(moves) == 0 {
+		return pass(s, seat)
+	}
+	board := s.Board()
+	pieces := s.Pieces()
+	best, bestScore := 0, 0
+	for i, m := range moves {
+		if i%256 == 0 {
+			if err := ctx.Err(); err != nil {
+				return blokus.Move{}, err
+			}
+		}
+		cells := moveCells(pieces[m.PieceIndex], m)
+		score := b.SizeWeight*len(cells) + b.AnchorWeight*newAnchors(board, m.Player.Color, cells)
+		if i == 0 || score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	return moves[best], nil
+}
+
+// moveCells returns the cells the move's piece covers.
+func moveCells(p *blokus.Piece, m blokus.Move) []blokus.Coord {
+	cells := m.Orient.TransformCoords(p.Blocks)
+	for i, c := range cells {
+		cells[i] = blokus.Coord{X: m.Loc.X + c.X, Y: m.Loc.Y + c.Y}
+	}
+	return cells
+}
+
+var (
+	edges     = []blokus.Coord{{X: -1, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: -1}, {X: 0, Y: 1}}
+	diagonals = []blokus.Coord{{X: -1, Y: -1}, {X: -1, Y: 1}, {X: 1, Y: -1}, {X: 1, Y: 1}}
+)
+
+// newAnchors returns the number of cells that become corner anchors of the color by placing the cells,
+// i.e. empty cells touching the new cells diagonally, that don't share an edge with the color,
+// and didn't touch the color diagonally before.
+func newAnchors(b *blokus.Board, color blokus.Color, cells []blokus.Coord) int {
+	placed := map[blokus.Coord]bool{}
+	for _, c := range cells {
+		placed[c] = true
+	}
+	hasColor := func(c blokus.Coord) bool {
+		return placed[c] || (!b.IsOutOfBounds(c) && b.Cell(c) == color)
+	}
+	seen := map[blokus.Coord]bool{}
+	n := 0
+	for _, c := range cells {
+	candidates:
+		for _, d := range diagonals {
+			a := blokus.Coord{X: c.X + d.X, Y: c.Y + d.Y}
+			if seen[a] || placed[a] || b.IsOutOfBounds(a) || b.Cell(a).IsColored() {
+				continue
+			}
+			seen[a] = true
+			for _, e := range edges {
+				if hasColor(blokus.Coord{X: a.X + e.X, Y: a.Y + e.Y}) {
+					continue candidates
+				}
+			}
+			for _, d := range diagonals {
+				o := blokus.Coord{X: a.X + d.X, Y: a.Y + d.Y}
+				if !placed[o] && !b.IsOutOfBounds(o) && b.Cell(o) == color {
+					continue candidates
+				}
+			}
+			n++
+		}
+	}
+	return n
+}
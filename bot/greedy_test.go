@@ -0,0 +1,59 @@
+package bot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hueich/blokus"
+)
+
+func TestGreedyPlaysLargePieces(t *testing.T) {
+	g := newGameOrDie(t, blokus.ClassicVariant())
+	for i := 0; i < 4; i++ {
+		r, err := Play(context.Background(), g, NewGreedy())
+		if err != nil {
+			t.Fatalf("Play(): got %v, want no error", err)
+		}
+		if got, want := g.Pieces[r.Move.PieceIndex].Size(), 5; got != want {
+			t.Errorf("Size of piece played by %v: got %d, want %d", r.Move.Player.Name, got, want)
+		}
+	}
+}
+
+func TestGreedyPrefersAnchors(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	s := g.Snapshot()
+	b := &Greedy{SizeWeight: 0, AnchorWeight: 1}
+	m, err := b.Move(context.Background(), s, 0)
+	if err != nil {
+		t.Fatalf("Move(): got %v, want no error", err)
+	}
+	best := newAnchors(s.Board(), blokus.Purple, moveCells(s.Pieces()[m.PieceIndex], m))
+	for _, other := range s.LegalMoves(0) {
+		if n := newAnchors(s.Board(), blokus.Purple, moveCells(s.Pieces()[other.PieceIndex], other)); n > best {
+			t.Fatalf("Anchors of greedy move %v: got %d, want at least %d of %v", s.FormatMove(m), best, n, s.FormatMove(other))
+		}
+	}
+	// The X pentomino in the middle of an empty board opens 8 anchors.
+	if got, want := best, 8; got != want {
+		t.Errorf("Anchors of greedy move %v: got %d, want %d", s.FormatMove(m), got, want)
+	}
+}
+
+func TestNewAnchors(t *testing.T) {
+	b, err := blokus.NewBoard(5)
+	if err != nil {
+		t.Fatalf("NewBoard(): got %v, want no error", err)
+	}
+	b.SetCell(blokus.Coord{X: 0, Y: 0}, blokus.Blue)
+	// A monomino diagonal to the existing one only adds the anchors not already touching blue.
+	cells := []blokus.Coord{{X: 1, Y: 1}}
+	if got, want := newAnchors(b, blokus.Blue, cells), 3; got != want {
+		t.Errorf("newAnchors(): got %d, want %d", got, want)
+	}
+	// Cells sharing an edge with blue are not anchors.
+	b.SetCell(blokus.Coord{X: 3, Y: 2}, blokus.Blue)
+	if got, want := newAnchors(b, blokus.Blue, cells), 2; got != want {
+		t.Errorf("newAnchors() next to blue: got %d, want %d", got, want)
+	}
+}
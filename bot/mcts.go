@@ -0,0 +1,424 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hueich/blokus"
+)
+
+// MCTS chooses moves with Monte Carlo tree search. Each search builds a tree of moves by playing out
+// short random continuations of the game, and picks the move that was explored the most.
+//
+// The search is max-n: each move in the tree is chosen for the person making it, so it works for any number
+// of players. Only the most promising moves of each position by the greedy heuristic are searched,
+// and playouts stop after a few moves to evaluate the position by score and room left to play.
+type MCTS struct {
+	// Playouts is the number of playouts per move, over all workers. Zero means no limit.
+	Playouts int
+	// Duration is how long to search per move. Zero means no limit, but either Playouts, Duration or
+	// the context's deadline must limit the search.
+	Duration time.Duration
+	// Workers is the number of goroutines searching their own tree, whose results are combined.
+	Workers int
+	// Exploration is the UCT constant weighing exploring less visited moves against exploiting good ones.
+	Exploration float64
+	// MaxBranch is the number of moves searched in each position, chosen by the greedy heuristic.
+	MaxBranch int
+	// PlayoutDepth is the number of moves played in each playout before the position is evaluated.
+	PlayoutDepth int
+	// Seed of the random number generators. With a seed of 0, the bot is seeded from the current time.
+	Seed int64
+}
+
+// NewMCTS creates a Monte Carlo tree search bot that searches for a second per move on every CPU.
+func NewMCTS() *MCTS {
+	return &MCTS{
+		Duration:     time.Second,
+		Workers:      runtime.NumCPU(),
+		Exploration:  0.25,
+		MaxBranch:    24,
+		PlayoutDepth: 8,
+	}
+}
+
+// mctsNode is a position in the search tree, reached by playing the node's move from its parent.
+type mctsNode struct {
+	parent *mctsNode
+	// Move played to reach this node. Its player is given by seat.
+	move blokus.Move
+	seat int
+	// Participant who chose the move, or -1 if unknown.
+	mover    int
+	children []*mctsNode
+	// Moves not expanded into children yet, best first. Nil until the node is first expanded.
+	untried  []blokus.Move
+	expanded bool
+	visits   int
+	// Sum of the rewards of each participant over the playouts through this node.
+	rewards []float64
+}
+
+func (b *MCTS) Move(ctx context.Context, s *blokus.Snapshot, seat int) (blokus.Move, error) {
+	players := s.Players()
+	if seat < 0 || seat >= len(players) {
+		return blokus.Move{}, fmt.Errorf("Seat out of range: %d", seat)
+	}
+	if s.CurrentPlayerIndex() != seat {
+		return blokus.Move{}, fmt.Errorf("It's not the turn of seat %d", seat)
+	}
+	if _, ok := ctx.Deadline(); !ok && b.Playouts <= 0 && b.Duration <= 0 {
+		return blokus.Move{}, fmt.Errorf("Search needs a playout count, duration or deadline")
+	}
+	if err := ctx.Err(); err != nil {
+		return blokus.Move{}, err
+	}
+	moves := s.LegalMoves(seat)
+	if len(moves) == 0 {
+		return pass(s, seat)
+	}
+	if len(moves) == 1 {
+		return moves[0], nil
+	}
+
+	if b.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.Duration)
+		defer cancel()
+	}
+	workers := b.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	// Every worker needs at least one playout, since zero would let it search without end.
+	if b.Playouts > 0 && workers > b.Playouts {
+		workers = b.Playouts
+	}
+	seed := b.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	// Each worker searches its own tree. Visits of the root's moves are summed afterwards.
+	roots := make([]*mctsNode, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		playouts := 0
+		if b.Playouts > 0 {
+			playouts = b.Playouts / workers
+			if w < b.Playouts%workers {
+				playouts++
+			}
+		}
+		wg.Add(1)
+		go func(w, playouts int) {
+			defer wg.Done()
+			search := &mctsSearch{
+				bot:  b,
+				root: s.Game(),
+				rnd:  rand.New(rand.NewSource(seed + int64(w))),
+			}
+			search.participants = participantIndexes(search.root)
+			roots[w], errs[w] = search.run(ctx, playouts)
+		}(w, playouts)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return blokus.Move{}, err
+		}
+	}
+
+	visits := map[blokus.Move]int{}
+	var order []blokus.Move
+	for _, root := range roots {
+		for _, c := range root.children {
+			key := c.move
+			key.Player = nil
+			if _, ok := visits[key]; !ok {
+				order = append(order, key)
+			}
+			visits[key] += c.visits
+		}
+	}
+	if len(order) == 0 {
+		if err := ctx.Err(); err != nil {
+			return blokus.Move{}, err
+		}
+		return blokus.Move{}, fmt.Errorf("Search made no playouts")
+	}
+	best := order[0]
+	for _, m := range order[1:] {
+		if visits[m] > visits[best] {
+			best = m
+		}
+	}
+	best.Player = players[seat]
+	return best, nil
+}
+
+// mctsSearch is the state of one worker.
+type mctsSearch struct {
+	bot  *MCTS
+	root *blokus.Game
+	rnd  *rand.Rand
+	// Index of the participant of each seat, or -1 for shared seats.
+	participants []int
+	numParts     int
+}
+
+// participantIndexes returns the index of each seat's participant in Game.Participants, or -1 for shared seats.
+func participantIndexes(g *blokus.Game) []int {
+	index := map[string]int{}
+	for i, name := range g.Participants() {
+		index[name] = i
+	}
+	parts := make([]int, len(g.Players))
+	for i, p := range g.Players {
+		parts[i] = -1
+		if !p.IsShared() {
+			parts[i] = index[p.Participant()]
+		}
+	}
+	return parts
+}
+
+// run searches until the playouts are done, or the context is done. Zero playouts means no limit.
+func (s *mctsSearch) run(ctx context.Context, playouts int) (*mctsNode, error) {
+	s.numParts = len(s.root.Participants())
+	root := &mctsNode{seat: -1, mover: -1, rewards: make([]float64, s.numParts)}
+	for i := 0; playouts <= 0 || i < playouts; i++ {
+		if i%8 == 0 && ctx.Err() != nil {
+			break
+		}
+		if err := s.iterate(root); err != nil {
+			return nil, err
+		}
+	}
+	return root, nil
+}
+
+// iterate selects a node to expand, plays out from it and updates the nodes on the way with the rewards.
+func (s *mctsSearch) iterate(root *mctsNode) error {
+	g := s.root.Clone()
+	n := root
+	for {
+		if !n.expanded {
+			s.expand(n, g)
+		}
+		if len(n.untried) > 0 {
+			m := n.untried[0]
+			n.untried = n.untried[1:]
+			child := &mctsNode{
+				parent:  n,
+				move:    m,
+				seat:    g.CurPlayerIndex,
+				mover:   moverOf(g, s.participants),
+				rewards: make([]float64, s.numParts),
+			}
+			n.children = append(n.children, child)
+			if err := s.apply(g, child); err != nil {
+				return err
+			}
+			n = child
+			break
+		}
+		if len(n.children) == 0 {
+			// The game ended.
+			break
+		}
+		n = s.selectChild(n)
+		if err := s.apply(g, n); err != nil {
+			return err
+		}
+	}
+
+	if err := s.playout(g); err != nil {
+		return err
+	}
+	rewards := s.rewards(g)
+	for ; n != nil; n = n.parent {
+		n.visits++
+		for i, r := range rewards {
+			n.rewards[i] += r
+		}
+	}
+	return nil
+}
+
+// expand fills in the moves to search from the node's position.
+func (s *mctsSearch) expand(n *mctsNode, g *blokus.Game) {
+	n.expanded = true
+	if g.IsGameEnd() {
+		return
+	}
+	player := g.CurrentPlayer()
+	moves := g.LegalMoves(player)
+	if len(moves) == 0 {
+		n.untried = []blokus.Move{{Player: player, PieceIndex: -1}}
+		return
+	}
+	n.untried = bestMoves(g, moves, s.bot.MaxBranch)
+}
+
+// bestMoves returns up to max of the moves in order of the greedy heuristic, i.e. largest pieces first,
+// then the most new corner anchors. Only moves with the largest pieces are scored, as long as there are enough.
+func bestMoves(g *blokus.Game, moves []blokus.Move, max int) []blokus.Move {
+	sort.SliceStable(moves, func(i, j int) bool {
+		return g.Pieces[moves[i].PieceIndex].Size() > g.Pieces[moves[j].PieceIndex].Size()
+	})
+	if max <= 0 || max > len(moves) {
+		max = len(moves)
+	}
+	// Score every move at least as large as the smallest piece that makes the cut.
+	minSize := g.Pieces[moves[max-1].PieceIndex].Size()
+	end := max
+	for end < len(moves) && g.Pieces[moves[end].PieceIndex].Size() >= minSize {
+		end++
+	}
+	candidates := moves[:end]
+	scores := make([]int, len(candidates))
+	greedy := NewGreedy()
+	for i, m := range candidates {
+		cells := moveCells(g.Pieces[m.PieceIndex], m)
+		scores[i] = greedy.SizeWeight*len(cells) + greedy.AnchorWeight*newAnchors(g.Board, m.Player.Color, cells)
+	}
+	sort.Sort(byScore{candidates, scores})
+	return append([]blokus.Move(nil), candidates[:max]...)
+}
+
+type byScore struct {
+	moves  []blokus.Move
+	scores []int
+}
+
+func (s byScore) Len() int           { return len(s.moves) }
+func (s byScore) Less(i, j int) bool { return s.scores[i] > s.scores[j] }
+func (s byScore) Swap(i, j int) {
+	s.moves[i], s.moves[j] = s.moves[j], s.moves[i]
+	s.scores[i], s.scores[j] = s.scores[j], s.scores[i]
+}
+
+// moverOf returns the participant choosing the next move of the game, or -1 if unknown.
+// Participants are given by seat as returned by participantIndexes.
+func moverOf(g *blokus.Game, participants []int) int {
+	seat := g.CurPlayerIndex
+	if p := participants[seat]; p >= 0 {
+		return p
+	}
+	controller := g.Controller(g.CurrentPlayer())
+	for i, name := range g.Participants() {
+		if name == controller {
+			return i
+		}
+	}
+	return -1
+}
+
+// apply plays the node's move in the game, which is at the node's parent position. Moves come from
+// the move generator of the same position, so an error means a bug in the rules rather than a bad move.
+func (s *mctsSearch) apply(g *blokus.Game, n *mctsNode) error {
+	m := n.move
+	m.Player = g.Players[n.seat]
+	if _, err := g.Play(m); err != nil {
+		return fmt.Errorf("Could not play searched move %v: %v", g.FormatMove(m), err)
+	}
+	return nil
+}
+
+// selectChild returns the child with the best upper confidence bound for the participant choosing the move.
+func (s *mctsSearch) selectChild(n *mctsNode) *mctsNode {
+	logVisits := math.Log(float64(n.visits))
+	var best *mctsNode
+	bestValue := math.Inf(-1)
+	for _, c := range n.children {
+		mean := 0.5
+		if c.mover >= 0 {
+			mean = c.rewards[c.mover] / float64(c.visits)
+		}
+		value := mean + s.bot.Exploration*math.Sqrt(logVisits/float64(c.visits))
+		if value > bestValue {
+			best, bestValue = c, value
+		}
+	}
+	return best
+}
+
+// playout plays a few moves from the position, each a random move of the largest piece that fits.
+func (s *mctsSearch) playout(g *blokus.Game) error {
+	for i := 0; i < s.bot.PlayoutDepth && !g.IsGameEnd(); i++ {
+		player := g.CurrentPlayer()
+		moves := g.LegalMoves(player)
+		m := blokus.Move{Player: player, PieceIndex: -1}
+		if len(moves) > 0 {
+			largest := 0
+			var best []blokus.Move
+			for _, lm := range moves {
+				switch size := g.Pieces[lm.PieceIndex].Size(); {
+				case size > largest:
+					largest, best = size, append(best[:0], lm)
+				case size == largest:
+					best = append(best, lm)
+				}
+			}
+			m = best[s.rnd.Intn(len(best))]
+		}
+		if _, err := g.Play(m); err != nil {
+			return fmt.Errorf("Could not play generated move %v: %v", g.FormatMove(m), err)
+		}
+	}
+	return nil
+}
+
+// rewards returns the reward of each participant for the position, between 0 and 1.
+func (s *mctsSearch) rewards(g *blokus.Game) []float64 {
+	values := evaluate(g, s.participants, s.numParts)
+	rewards := make([]float64, len(values))
+	for i, v := range values {
+		bestOther := math.Inf(-1)
+		for j, o := range values {
+			if j != i && o > bestOther {
+				bestOther = o
+			}
+		}
+		if len(values) == 1 {
+			bestOther = 0
+		}
+		// Scale so that being a pentomino ahead is a clear advantage.
+		rewards[i] = 0.5 + 0.5*math.Tanh((v-bestOther)/5)
+	}
+	return rewards
+}
+
+// anchorWeight is the value of the corner anchors of a player in evaluate, scaled by their square root
+// since only a few of them are usually playable.
+const anchorWeight = 1.5
+
+// evaluate returns the value of the position for each participant, which is the score,
+// plus some credit for the corner anchors left to play from while the game goes on.
+func evaluate(g *blokus.Game, participants []int, numParts int) []float64 {
+	values := make([]float64, numParts)
+	ended := g.IsGameEnd()
+	for i, p := range g.Players {
+		part := participants[i]
+		if part < 0 {
+			continue
+		}
+		score, err := g.Score(p)
+		if err != nil {
+			continue
+		}
+		values[part] += float64(score.Total)
+		if !ended && p.Status.IsActive() {
+			values[part] += anchorWeight * math.Sqrt(float64(len(g.Board.CornerAnchors(p.Color))))
+		}
+	}
+	return values
+}
@@ -0,0 +1,91 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/hueich/blokus"
+)
+
+// newTestMCTS returns an MCTS bot whose search is limited by playouts, so it plays the same moves every time.
+func newTestMCTS(playouts int, seed int64) *MCTS {
+	b := NewMCTS()
+	b.Duration = 0
+	b.Playouts = playouts
+	b.Workers = 2
+	b.Seed = seed
+	return b
+}
+
+func TestMCTSBeatsGreedy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping full games in short mode")
+	}
+	for game := 0; game < 2; game++ {
+		g := newGameOrDie(t, blokus.TwoPlayerVariant())
+		seat := game % 2
+		bots := []Bot{NewGreedy(), NewGreedy()}
+		bots[seat] = newTestMCTS(160, int64(game+1))
+		playToEnd(t, g, bots)
+		winners, err := g.Winners()
+		if err != nil {
+			t.Fatalf("Winners(): got %v, want no error", err)
+		}
+		if got, want := winners, []string{g.Players[seat].Participant()}; len(got) != 1 || got[0] != want[0] {
+			t.Errorf("Winners of game %d: got %v, want %v", game, got, want)
+		}
+	}
+}
+
+func TestMCTSPlaysLegalMoves(t *testing.T) {
+	g := newGameOrDie(t, blokus.ClassicVariant())
+	b := newTestMCTS(40, 1)
+	b.Workers = 3
+	for i := 0; i < 8; i++ {
+		if _, err := Play(context.Background(), g, b); err != nil {
+			t.Fatalf("Play() at turn %d: got %v, want no error", i, err)
+		}
+	}
+	if err := g.Verify(); err != nil {
+		t.Errorf("Verify(): got %v, want no error", err)
+	}
+}
+
+func TestMCTSFewerPlayoutsThanWorkers(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	b := newTestMCTS(2, 1)
+	b.Workers = 8
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if _, err := Play(ctx, g, b); err != nil {
+		t.Fatalf("Play(): got %v, want no error", err)
+	}
+	if err := ctx.Err(); err != nil {
+		t.Errorf("Search with %d playouts on %d workers: got %v, want to finish before the deadline", b.Playouts, b.Workers, err)
+	}
+}
+
+func TestMCTSDuration(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	b := NewMCTS()
+	b.Duration = 50 * time.Millisecond
+	start := time.Now()
+	if _, err := Play(context.Background(), g, b); err != nil {
+		t.Fatalf("Play(): got %v, want no error", err)
+	}
+	if got, max := time.Since(start), 2*time.Second; got > max {
+		t.Errorf("Search time: got %v, want at most %v", got, max)
+	}
+}
+
+func TestMCTSIllegalMove(t *testing.T) {
+	g := openedGame(t)
+	s := &mctsSearch{bot: newTestMCTS(10, 1), root: g, rnd: rand.New(rand.NewSource(1))}
+	// A move of a piece that was already placed can't be played.
+	n := &mctsNode{move: *g.Moves[len(g.Moves)-2], seat: g.CurPlayerIndex}
+	if err := s.apply(g.Clone(), n); err == nil {
+		t.Errorf("apply(%v): got no error, want error", g.FormatMove(n.move))
+	}
+}
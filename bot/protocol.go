@@ -0,0 +1,290 @@
+package bot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hueich/blokus"
+)
+
+// The engine protocol lets a bot run in its own process, in any language, talking over stdin and stdout.
+// It's a line based text protocol in the style of UCI. Commands from the game to the engine are:
+//
+//	blokus                       sent first; the engine answers "id name <name>", then "blokusok"
+//	isready                      the engine answers "readyok" once it's done with the earlier commands
+//	newgame                      forgets the game setup
+//	setup [Tag "value"]          adds a tag pair of the game setup, as in game records, e.g. setup [BoardSize "20"]
+//	position [moves m1; m2; ...] sets the position after the moves in move notation, played from the setup
+//	go [time <ms>]               searches the position for at most the time; the engine answers "bestmove <move>"
+//	stop                         asks the engine to answer "bestmove" as soon as possible
+//	quit                         exits the engine
+//
+// Answers from the engine to the game are:
+//
+//	id name <name>               the name of the engine
+//	blokusok                     the engine is ready for the game
+//	readyok                      the answer to "isready"
+//	bestmove <move>              the move to play in move notation, e.g. "bestmove B F5 r1f @ 4,7" or "bestmove Y pass"
+//	error <message>              a command failed, e.g. the position has an illegal move
+//	info string <text>           anything to log, which is ignored like any other unknown line
+
+const (
+	cmdBlokus   = "blokus"
+	cmdIsReady  = "isready"
+	cmdNewGame  = "newgame"
+	cmdSetup    = "setup"
+	cmdPosition = "position"
+	cmdGo       = "go"
+	cmdStop     = "stop"
+	cmdQuit     = "quit"
+
+	replyID       = "id"
+	replyBlokusOK = "blokusok"
+	replyReadyOK  = "readyok"
+	replyBestMove = "bestmove"
+	replyError    = "error"
+
+	// Separator of the moves in the position command.
+	moveSeparator = ";"
+)
+
+// Serve runs the bot as an engine, reading commands from r and writing answers to w until
+// the quit command, the end of the input or the context is done. At the end of the input,
+// a search still going on is answered first.
+func Serve(ctx context.Context, b Bot, name string, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lines := make(chan string)
+	readErr := make(chan error, 1)
+	go func() {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+		close(lines)
+	}()
+
+	e := &engineState{bot: b, name: name, w: bufio.NewWriter(w)}
+	defer e.stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case res := <-e.results:
+			err = e.finish(res)
+		case line, ok := <-lines:
+			if !ok {
+				// Answer the last search before exiting, as when the commands are piped from a file.
+				if e.results != nil {
+					if err := e.finish(<-e.results); err != nil {
+						return err
+					}
+				}
+				if err := <-readErr; err != nil {
+					return err
+				}
+				return e.w.Flush()
+			}
+			var quit bool
+			if quit, err = e.handle(ctx, line); quit {
+				return e.w.Flush()
+			}
+		}
+		if err == nil {
+			err = e.w.Flush()
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// engineState is the state of an engine served by Serve.
+type engineState struct {
+	bot  Bot
+	name string
+	w    *bufio.Writer
+	// Tag lines of the game setup.
+	setup []string
+	// Game at the current position, or nil if there's none.
+	game *blokus.Game
+	// Set while searching, to stop the search and to receive its result.
+	cancel  context.CancelFunc
+	results chan searchResult
+}
+
+type searchResult struct {
+	game *blokus.Game
+	move blokus.Move
+	err  error
+}
+
+// handle runs the command, and returns whether it was the quit command.
+func (e *engineState) handle(ctx context.Context, line string) (bool, error) {
+	cmd, args := splitCommand(line)
+	switch cmd {
+	case "":
+	case cmdBlokus:
+		fmt.Fprintf(e.w, "%s name %s\n", replyID, e.name)
+		fmt.Fprintln(e.w, replyBlokusOK)
+	case cmdIsReady:
+		fmt.Fprintln(e.w, replyReadyOK)
+	case cmdNewGame:
+		e.setup = nil
+		e.game = nil
+	case cmdSetup:
+		if !strings.HasPrefix(args, "[") {
+			e.fail(fmt.Errorf("Setup must be a tag pair like [Name \"value\"]: %q", args))
+			break
+		}
+		e.setup = append(e.setup, args)
+		e.game = nil
+	case cmdPosition:
+		g, err := e.position(args)
+		if err != nil {
+			e.fail(err)
+		}
+		e.game = g
+	case cmdGo:
+		e.fail(e.start(ctx, args))
+	case cmdStop:
+		if e.cancel != nil {
+			e.cancel()
+		}
+	case cmdQuit:
+		return true, nil
+	default:
+		e.fail(fmt.Errorf("Unknown command: %v", cmd))
+	}
+	return false, nil
+}
+
+// position creates the game from the setup, and plays the moves listed after "moves".
+func (e *engineState) position(args string) (*blokus.Game, error) {
+	var text bytes.Buffer
+	for _, line := range e.setup {
+		fmt.Fprintln(&text, line)
+	}
+	fmt.Fprintln(&text)
+	if args = strings.TrimSpace(args); len(args) > 0 {
+		cmd, moves := splitCommand(args)
+		if cmd != "moves" {
+			return nil, fmt.Errorf("Position must be followed by moves: %q", args)
+		}
+		for _, m := range strings.Split(moves, moveSeparator) {
+			fmt.Fprintln(&text, strings.TrimSpace(m))
+		}
+	}
+	r, err := blokus.ReadRecord(&text)
+	if err != nil {
+		return nil, err
+	}
+	return r.Replay()
+}
+
+// start starts searching for the move of the current player in the background.
+func (e *engineState) start(ctx context.Context, args string) error {
+	if e.cancel != nil {
+		return fmt.Errorf("Already searching")
+	}
+	if e.game == nil {
+		return fmt.Errorf("No position to search")
+	}
+	if e.game.IsGameEnd() {
+		return fmt.Errorf("Game has already ended")
+	}
+	var cancel context.CancelFunc
+	if fields := strings.Fields(args); len(fields) > 0 {
+		ms, err := strconv.Atoi(fields[len(fields)-1])
+		if len(fields) != 2 || fields[0] != "time" || err != nil || ms <= 0 {
+			return fmt.Errorf("Go must be followed by nothing or time in milliseconds: %q", args)
+		}
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(ms)*time.Millisecond)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	g := e.game
+	snapshot := g.Snapshot()
+	results := make(chan searchResult, 1)
+	e.cancel, e.results = cancel, results
+	go func() {
+		m, err := e.bot.Move(ctx, snapshot, g.CurPlayerIndex)
+		results <- searchResult{game: g, move: m, err: err}
+	}()
+	return nil
+}
+
+// finish answers with the result of the search.
+func (e *engineState) finish(res searchResult) error {
+	e.cancel()
+	e.cancel, e.results = nil, nil
+	if res.err != nil {
+		e.fail(res.err)
+		return nil
+	}
+	_, err := fmt.Fprintf(e.w, "%s %s\n", replyBestMove, res.game.FormatMove(res.move))
+	return err
+}
+
+// stop stops the search if there's one, and waits for it to end.
+func (e *engineState) stop() {
+	if e.cancel != nil {
+		e.cancel()
+		<-e.results
+	}
+}
+
+func (e *engineState) fail(err error) {
+	if err != nil {
+		fmt.Fprintf(e.w, "%s %s\n", replyError, strings.ReplaceAll(err.Error(), "\n", " "))
+	}
+}
+
+// splitCommand splits the line into the first word and the rest.
+func splitCommand(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		return line[:i], strings.TrimSpace(line[i:])
+	}
+	return line, ""
+}
+
+// setupLines returns the tag lines of the game's setup, as sent with the setup command.
+func setupLines(g *blokus.Game) ([]string, error) {
+	var b bytes.Buffer
+	if err := blokus.WriteRecord(&b, &blokus.Record{Setup: g.Setup()}); err != nil {
+		return nil, err
+	}
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		if strings.HasPrefix(line, "[") {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// positionLine returns the position command for the moves played in the game.
+func positionLine(g *blokus.Game) string {
+	if len(g.Moves) == 0 {
+		return cmdPosition
+	}
+	moves := make([]string, 0, len(g.Moves))
+	for _, m := range g.Moves {
+		moves = append(moves, g.FormatMove(*m))
+	}
+	return fmt.Sprintf("%s moves %s", cmdPosition, strings.Join(moves, moveSeparator+" "))
+}
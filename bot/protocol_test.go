@@ -0,0 +1,130 @@
+package bot
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hueich/blokus"
+)
+
+// waitingBot plays the greedy move once the context is done.
+type waitingBot struct{}
+
+func (waitingBot) Move(ctx context.Context, s *blokus.Snapshot, seat int) (blokus.Move, error) {
+	<-ctx.Done()
+	return NewGreedy().Move(context.Background(), s, seat)
+}
+
+// setupCommands returns the commands that set up the game and its position.
+func setupCommands(t *testing.T, g *blokus.Game) []string {
+	lines, err := setupLines(g)
+	if err != nil {
+		t.Fatalf("setupLines(): got %v, want no error", err)
+	}
+	cmds := []string{"newgame"}
+	for _, line := range lines {
+		cmds = append(cmds, "setup "+line)
+	}
+	return append(cmds, positionLine(g))
+}
+
+// serve runs the commands through Serve, and returns the answers.
+func serve(t *testing.T, b Bot, cmds []string) []string {
+	var out bytes.Buffer
+	if err := Serve(context.Background(), b, "test", strings.NewReader(strings.Join(cmds, "\n")), &out); err != nil {
+		t.Fatalf("Serve(): got %v, want no error", err)
+	}
+	return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+}
+
+func TestServe(t *testing.T) {
+	g := openedGame(t)
+	want, err := NewGreedy().Move(context.Background(), g.Snapshot(), g.CurPlayerIndex)
+	if err != nil {
+		t.Fatalf("Move(): got %v, want no error", err)
+	}
+	cmds := append([]string{"blokus", "isready"}, setupCommands(t, g)...)
+	cmds = append(cmds, "go time 1000")
+	got := serve(t, NewGreedy(), cmds)
+	wantLines := []string{"id name test", "blokusok", "readyok", "bestmove " + g.FormatMove(want)}
+	if strings.Join(got, "\n") != strings.Join(wantLines, "\n") {
+		t.Errorf("Serve() answers: got %q, want %q", got, wantLines)
+	}
+}
+
+func TestServeErrors(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	setup := setupCommands(t, g)
+	for _, tc := range []struct {
+		desc string
+		cmds []string
+	}{
+		{"unknown command", []string{"think"}},
+		{"go without position", []string{"go"}},
+		{"setup without tag", []string{"setup BoardSize 14"}},
+		{"illegal move", append(setup[:len(setup)-1:len(setup)-1], "position moves P X5 @ 0,0")},
+		{"move without color", append(setup[:len(setup)-1:len(setup)-1], "position moves X5 @ 3,3")},
+		{"position without moves", append(setup[:len(setup)-1:len(setup)-1], "position X5 @ 3,3")},
+		{"invalid time", append(setup, "go time soon")},
+	} {
+		got := serve(t, NewGreedy(), tc.cmds)
+		if len(got) != 1 || !strings.HasPrefix(got[0], "error ") {
+			t.Errorf("Serve() with %v: got %q, want one error", tc.desc, got)
+		}
+	}
+}
+
+func TestServeStop(t *testing.T) {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	in, inWriter := io.Pipe()
+	outReader, out := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(context.Background(), waitingBot{}, "test", in, out)
+	}()
+	answers := bufio.NewScanner(outReader)
+
+	cmds := append(setupCommands(t, g), "go", "isready")
+	if _, err := io.WriteString(inWriter, strings.Join(cmds, "\n")+"\n"); err != nil {
+		t.Fatalf("Write(): got %v, want no error", err)
+	}
+	// The engine answers while searching.
+	if !answers.Scan() || answers.Text() != "readyok" {
+		t.Fatalf("Answer to isready: got %q, want readyok", answers.Text())
+	}
+	io.WriteString(inWriter, "stop\n")
+	if !answers.Scan() || !strings.HasPrefix(answers.Text(), "bestmove P ") {
+		t.Errorf("Answer to stop: got %q, want bestmove", answers.Text())
+	}
+	io.WriteString(inWriter, "quit\n")
+	if err := <-done; err != nil {
+		t.Errorf("Serve(): got %v, want no error", err)
+	}
+}
+
+func TestServePosition(t *testing.T) {
+	resigned := openedGame(t)
+	if err := resigned.Resign(resigned.Players[1]); err != nil {
+		t.Fatalf("Resign(): got %v, want no error", err)
+	}
+	for _, g := range []*blokus.Game{openedGame(t), resigned} {
+		e := &engineState{}
+		cmds := setupCommands(t, g)
+		for _, cmd := range cmds[1 : len(cmds)-1] {
+			_, args := splitCommand(cmd)
+			e.setup = append(e.setup, args)
+		}
+		_, args := splitCommand(cmds[len(cmds)-1])
+		got, err := e.position(args)
+		if err != nil {
+			t.Fatalf("position(%v): got %v, want no error", args, err)
+		}
+		if got.Hash() != g.Hash() {
+			t.Errorf("Position of %q: got hash %x, want %x", args, got.Hash(), g.Hash())
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package bot
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/hueich/blokus"
+)
+
+// Random plays a legal move chosen uniformly at random.
+type Random struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+// NewRandom creates a random bot. With a seed of 0, the bot is seeded from the current time.
+func NewRandom(seed int64) *Random {
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return &Random{rnd: rand.New(rand.NewSource(seed))}
+}
+
+func (b *Random) Move(ctx context.Context, s *blokus.Snapshot, seat int) (blokus.Move, error) {
+	if err := ctx.Err(); err != nil {
+		return blokus.Move{}, err
+	}
+	moves := s.LegalMoves(seat)
+	if len(moves) == 0 {
+		return pass(s, seat)
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return moves[b.rnd.Intn(len(moves))], nil
+}
@@ -0,0 +1,22 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/hueich/blokus"
+)
+
+func TestRandomIsReproducible(t *testing.T) {
+	g1 := newGameOrDie(t, blokus.DuoVariant())
+	g2 := newGameOrDie(t, blokus.DuoVariant())
+	playToEnd(t, g1, []Bot{NewRandom(7)})
+	playToEnd(t, g2, []Bot{NewRandom(7)})
+	if got, want := len(g1.Moves), len(g2.Moves); got != want {
+		t.Fatalf("Moves with same seed: got %d and %d moves, want same", got, want)
+	}
+	for i, m := range g1.Moves {
+		if got, want := g1.FormatMove(*m), g2.FormatMove(*g2.Moves[i]); got != want {
+			t.Errorf("Move %d with same seed: got %v and %v, want same", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/hueich/blokus"
+)
+
+// SearchMode is how a search bot expects the other people to choose their moves.
+type SearchMode int
+
+const (
+	// MaxN assumes everyone plays the move that is best for themselves.
+	MaxN SearchMode = iota
+	// Paranoid assumes everyone else plays against the bot, so moves can be pruned with alpha-beta.
+	Paranoid
+)
+
+func (m SearchMode) String() string {
+	switch m {
+	case MaxN:
+		return "max-n"
+	case Paranoid:
+		return "paranoid"
+	}
+	return "unknown search mode"
+}
+
+// Search chooses moves by searching the game tree a few moves deep and evaluating the positions reached.
+// The search deepens one move at a time until it reaches Depth or runs out of time, playing the best move
+// of the deepest search finished. Without a duration or deadline the search always reaches Depth,
+// so the bot plays the same move in the same position every time.
+type Search struct {
+	// Mode is how the other people are expected to play.
+	Mode SearchMode
+	// Depth is the largest number of moves to search ahead. Zero means no limit, but either Depth, Duration or
+	// the context's deadline must limit the search.
+	Depth int
+	// Duration is how long to search per move. Zero means no limit.
+	Duration time.Duration
+	// MaxBranch is the number of moves searched in each position, chosen by the greedy heuristic.
+	// Zero means all legal moves.
+	MaxBranch int
+	// Eval judges the positions at the end of the search.
+	Eval Evaluator
+}
+
+// NewSearch creates a search bot of the mode that searches up to 3 moves ahead for at most a second per move.
+func NewSearch(mode SearchMode) *Search {
+	return &Search{
+		Mode:      mode,
+		Depth:     3,
+		Duration:  time.Second,
+		MaxBranch: 12,
+		Eval:      NewEvaluation(),
+	}
+}
+
+// errSearchTimeout stops a search that ran out of time.
+var errSearchTimeout = errors.New("Search ran out of time")
+
+func (b *Search) Move(ctx context.Context, s *blokus.Snapshot, seat int) (blokus.Move, error) {
+	players := s.Players()
+	if seat < 0 || seat >= len(players) {
+		return blokus.Move{}, fmt.Errorf("Seat out of range: %d", seat)
+	}
+	if s.CurrentPlayerIndex() != seat {
+		return blokus.Move{}, fmt.Errorf("It's not the turn of seat %d", seat)
+	}
+	if _, ok := ctx.Deadline(); !ok && b.Depth <= 0 && b.Duration <= 0 {
+		return blokus.Move{}, fmt.Errorf("Search needs a depth, duration or deadline")
+	}
+	if b.Eval == nil {
+		return blokus.Move{}, fmt.Errorf("Search has no evaluator")
+	}
+	if err := ctx.Err(); err != nil {
+		return blokus.Move{}, err
+	}
+	moves := s.LegalMoves(seat)
+	if len(moves) == 0 {
+		return pass(s, seat)
+	}
+	if len(moves) == 1 {
+		return moves[0], nil
+	}
+
+	if b.Duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, b.Duration)
+		defer cancel()
+	}
+	g := s.Game()
+	search := &treeSearch{
+		bot:          b,
+		ctx:          ctx,
+		participants: participantIndexes(g),
+		numParts:     len(g.Participants()),
+	}
+	search.me = moverOf(g, search.participants)
+	root := bestMoves(g, moves, b.MaxBranch)
+	best := root[0]
+	for depth := 1; b.Depth <= 0 || depth <= b.Depth; depth++ {
+		search.cutoff = false
+		i, err := search.root(g, root, depth)
+		if err == errSearchTimeout {
+			break
+		}
+		if err != nil {
+			return blokus.Move{}, err
+		}
+		best = root[i]
+		// Search the best move first at the next depth, which prunes more in paranoid mode.
+		root = append([]blokus.Move{best}, append(root[:i:i], root[i+1:]...)...)
+		if !search.cutoff {
+			// The whole game tree was searched, so searching deeper changes nothing.
+			break
+		}
+	}
+	best.Player = players[seat]
+	return best, nil
+}
+
+// treeSearch is the state of one search.
+type treeSearch struct {
+	bot *Search
+	ctx context.Context
+	// Index of the participant of each seat, or -1 for shared seats.
+	participants []int
+	numParts     int
+	// Participant the search is for, or -1 if unknown.
+	me int
+	// Whether any position was evaluated because of the depth limit rather than the end of the game.
+	cutoff bool
+}
+
+// root searches each of the moves to the depth, and returns the index of the best one.
+func (s *treeSearch) root(g *blokus.Game, moves []blokus.Move, depth int) (int, error) {
+	best := 0
+	switch s.bot.Mode {
+	case MaxN:
+		bestValue := math.Inf(-1)
+		for i, m := range moves {
+			c, err := s.play(g, m)
+			if err != nil {
+				return 0, err
+			}
+			values, err := s.maxN(c, depth-1)
+			if err != nil {
+				return 0, err
+			}
+			if s.me >= 0 && values[s.me] > bestValue {
+				best, bestValue = i, values[s.me]
+			}
+		}
+	case Paranoid:
+		alpha := math.Inf(-1)
+		for i, m := range moves {
+			c, err := s.play(g, m)
+			if err != nil {
+				return 0, err
+			}
+			v, err := s.paranoid(c, depth-1, alpha, math.Inf(1))
+			if err != nil {
+				return 0, err
+			}
+			if v > alpha || i == 0 {
+				best, alpha = i, v
+			}
+		}
+	default:
+		return 0, fmt.Errorf("Unknown search mode: %d", s.bot.Mode)
+	}
+	return best, nil
+}
+
+// maxN returns the values of the position for each participant, with each move chosen by whoever makes it.
+func (s *treeSearch) maxN(g *blokus.Game, depth int) ([]float64, error) {
+	if err := s.visit(); err != nil {
+		return nil, err
+	}
+	if g.IsGameEnd() || depth == 0 {
+		return s.evaluate(g, depth), nil
+	}
+	mover := moverOf(g, s.participants)
+	var best []float64
+	for _, m := range s.moves(g) {
+		c, err := s.play(g, m)
+		if err != nil {
+			return nil, err
+		}
+		values, err := s.maxN(c, depth-1)
+		if err != nil {
+			return nil, err
+		}
+		// Without a known mover, the first move by the heuristic is assumed.
+		if best == nil || (mover >= 0 && values[mover] > best[mover]) {
+			best = values
+		}
+	}
+	return best, nil
+}
+
+// paranoid returns the value of the position for the searching participant, with everyone else playing against
+// them. Values outside of alpha and beta only bound the actual value.
+func (s *treeSearch) paranoid(g *blokus.Game, depth int, alpha, beta float64) (float64, error) {
+	if err := s.visit(); err != nil {
+		return 0, err
+	}
+	if g.IsGameEnd() || depth == 0 {
+		return s.value(s.evaluate(g, depth)), nil
+	}
+	maximize := s.me >= 0 && moverOf(g, s.participants) == s.me
+	for _, m := range s.moves(g) {
+		c, err := s.play(g, m)
+		if err != nil {
+			return 0, err
+		}
+		v, err := s.paranoid(c, depth-1, alpha, beta)
+		if err != nil {
+			return 0, err
+		}
+		if maximize {
+			alpha = math.Max(alpha, v)
+		} else {
+			beta = math.Min(beta, v)
+		}
+		if alpha >= beta {
+			break
+		}
+	}
+	if maximize {
+		return alpha, nil
+	}
+	return beta, nil
+}
+
+// visit returns an error if the search ran out of time. It's checked at every position,
+// since evaluating a position takes much longer than checking the context.
+func (s *treeSearch) visit() error {
+	if s.ctx.Err() != nil {
+		return errSearchTimeout
+	}
+	return nil
+}
+
+// moves returns the moves to search from the position, which is a pass if there are no legal moves.
+func (s *treeSearch) moves(g *blokus.Game) []blokus.Move {
+	player := g.CurrentPlayer()
+	moves := g.LegalMoves(player)
+	if len(moves) == 0 {
+		return []blokus.Move{{Player: player, PieceIndex: -1}}
+	}
+	return bestMoves(g, moves, s.bot.MaxBranch)
+}
+
+// play returns a copy of the game with the move played. Moves come from the move generator
+// of the same position, so an error means a bug in the rules rather than a bad move.
+func (s *treeSearch) play(g *blokus.Game, m blokus.Move) (*blokus.Game, error) {
+	c := g.Clone()
+	m.Player = c.Players[g.CurPlayerIndex]
+	if _, err := c.Play(m); err != nil {
+		return nil, fmt.Errorf("Could not play searched move %v: %v", c.FormatMove(m), err)
+	}
+	return c, nil
+}
+
+// evaluate returns the value of the position for each participant, adding up the values of their seats.
+func (s *treeSearch) evaluate(g *blokus.Game, depth int) []float64 {
+	if depth == 0 && !g.IsGameEnd() {
+		s.cutoff = true
+	}
+	values := make([]float64, s.numParts)
+	for i, v := range s.bot.Eval.Evaluate(g) {
+		if part := s.participants[i]; part >= 0 {
+			values[part] += v
+		}
+	}
+	return values
+}
+
+// value returns how good the participants' values are for the searching participant,
+// i.e. the lead over the best of the others.
+func (s *treeSearch) value(values []float64) float64 {
+	if s.me < 0 {
+		return 0
+	}
+	bestOther := math.Inf(-1)
+	for i, v := range values {
+		if i != s.me && v > bestOther {
+			bestOther = v
+		}
+	}
+	if math.IsInf(bestOther, -1) {
+		return values[s.me]
+	}
+	return values[s.me] - bestOther
+}
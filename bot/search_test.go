@@ -0,0 +1,182 @@
+package bot
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/hueich/blokus"
+)
+
+// anchorEvaluator values each player by score and corner anchors, which is quick to evaluate.
+type anchorEvaluator struct{}
+
+func (anchorEvaluator) Evaluate(g *blokus.Game) []float64 {
+	values := make([]float64, len(g.Players))
+	for i, p := range g.Players {
+		score, _ := g.Score(p)
+		values[i] = float64(score.Total + len(g.Board.CornerAnchors(p.Color)))
+	}
+	return values
+}
+
+// newTestSearch returns a search bot that always searches to the depth, so it plays the same moves every time.
+func newTestSearch(mode SearchMode, depth int) *Search {
+	b := NewSearch(mode)
+	b.Depth = depth
+	b.Duration = 0
+	b.MaxBranch = 6
+	return b
+}
+
+// openedGame returns a Duo game after a few greedy moves.
+func openedGame(t *testing.T) *blokus.Game {
+	g := newGameOrDie(t, blokus.DuoVariant())
+	for i := 0; i < 4; i++ {
+		if _, err := Play(context.Background(), g, NewGreedy()); err != nil {
+			t.Fatalf("Play(): got %v, want no error", err)
+		}
+	}
+	return g
+}
+
+func TestSearchPlaysGame(t *testing.T) {
+	for _, mode := range []SearchMode{MaxN, Paranoid} {
+		g := newGameOrDie(t, blokus.DuoVariant())
+		playToEnd(t, g, []Bot{newTestSearch(mode, 1), NewGreedy()})
+		if err := g.Verify(); err != nil {
+			t.Errorf("Verify() after %v game: got %v, want no error", mode, err)
+		}
+	}
+}
+
+func TestSearchIsDeterministic(t *testing.T) {
+	g := openedGame(t)
+	for _, mode := range []SearchMode{MaxN, Paranoid} {
+		b := newTestSearch(mode, 2)
+		first, err := b.Move(context.Background(), g.Snapshot(), g.CurPlayerIndex)
+		if err != nil {
+			t.Fatalf("Move(): got %v, want no error", err)
+		}
+		second, err := b.Move(context.Background(), g.Snapshot(), g.CurPlayerIndex)
+		if err != nil {
+			t.Fatalf("Move(): got %v, want no error", err)
+		}
+		if got, want := g.FormatMove(second), g.FormatMove(first); got != want {
+			t.Errorf("Second %v move: got %v, want %v", mode, got, want)
+		}
+	}
+}
+
+func playOrFail(t *testing.T, s *treeSearch, g *blokus.Game, m blokus.Move) *blokus.Game {
+	c, err := s.play(g, m)
+	if err != nil {
+		t.Fatalf("play(%v): got %v, want no error", g.FormatMove(m), err)
+	}
+	return c
+}
+
+// minimax returns the paranoid value of the position without pruning.
+func minimax(t *testing.T, s *treeSearch, g *blokus.Game, depth int) float64 {
+	if g.IsGameEnd() || depth == 0 {
+		return s.value(s.evaluate(g, depth))
+	}
+	maximize := moverOf(g, s.participants) == s.me
+	best := math.Inf(1)
+	if maximize {
+		best = math.Inf(-1)
+	}
+	for _, m := range s.moves(g) {
+		v := minimax(t, s, playOrFail(t, s, g, m), depth-1)
+		if (maximize && v > best) || (!maximize && v < best) {
+			best = v
+		}
+	}
+	return best
+}
+
+func TestParanoidPruning(t *testing.T) {
+	g := openedGame(t)
+	b := newTestSearch(Paranoid, 3)
+	b.Eval = anchorEvaluator{}
+	s := &treeSearch{bot: b, ctx: context.Background(), participants: participantIndexes(g), numParts: 2}
+	s.me = moverOf(g, s.participants)
+	moves := s.moves(g)
+
+	best, bestValue := 0, math.Inf(-1)
+	for i, m := range moves {
+		if v := minimax(t, s, playOrFail(t, s, g, m), b.Depth-1); v > bestValue {
+			best, bestValue = i, v
+		}
+	}
+	got, err := s.root(g, moves, b.Depth)
+	if err != nil {
+		t.Fatalf("root(): got %v, want no error", err)
+	}
+	if want := best; got != want {
+		t.Errorf("Best move with alpha-beta: got %v, want %v", g.FormatMove(moves[got]), g.FormatMove(moves[want]))
+	}
+	v, err := s.paranoid(g, b.Depth, math.Inf(-1), math.Inf(1))
+	if err != nil {
+		t.Fatalf("paranoid(): got %v, want no error", err)
+	}
+	if got, want := v, bestValue; got != want {
+		t.Errorf("paranoid(): got %v, want %v", got, want)
+	}
+}
+
+func TestMaxNPicksBestMove(t *testing.T) {
+	g := openedGame(t)
+	b := newTestSearch(MaxN, 1)
+	b.Eval = anchorEvaluator{}
+	b.MaxBranch = 0
+	m, err := b.Move(context.Background(), g.Snapshot(), g.CurPlayerIndex)
+	if err != nil {
+		t.Fatalf("Move(): got %v, want no error", err)
+	}
+	value := func(m blokus.Move) float64 {
+		c := g.Clone()
+		m.Player = c.CurrentPlayer()
+		if _, err := c.Play(m); err != nil {
+			t.Fatalf("Play(%v): got %v, want no error", g.FormatMove(m), err)
+		}
+		return anchorEvaluator{}.Evaluate(c)[g.CurPlayerIndex]
+	}
+	got := value(m)
+	for _, other := range g.LegalMoves(g.CurrentPlayer()) {
+		if v := value(other); v > got {
+			t.Fatalf("Value of move %v: got %v, want at least %v of %v", g.FormatMove(m), got, v, g.FormatMove(other))
+		}
+	}
+}
+
+func TestSearchDuration(t *testing.T) {
+	g := openedGame(t)
+	b := NewSearch(Paranoid)
+	b.Depth = 0
+	b.Duration = 100 * time.Millisecond
+	start := time.Now()
+	if _, err := Play(context.Background(), g, b); err != nil {
+		t.Fatalf("Play(): got %v, want no error", err)
+	}
+	if got, max := time.Since(start), 2*time.Second; got > max {
+		t.Errorf("Search time: got %v, want at most %v", got, max)
+	}
+}
+
+func TestSearchIllegalMove(t *testing.T) {
+	g := openedGame(t)
+	s := &treeSearch{bot: newTestSearch(MaxN, 1), ctx: context.Background(), participants: participantIndexes(g), numParts: 2}
+	// A move of a piece that was already placed can't be played.
+	m := *g.Moves[len(g.Moves)-2]
+	if _, err := s.play(g, m); err == nil {
+		t.Errorf("play(%v): got no error, want error", g.FormatMove(m))
+	}
+}
+
+func TestSearchModeString(t *testing.T) {
+	if got, want := Paranoid.String(), "paranoid"; got != want {
+		t.Errorf("String(): got %v, want %v", got, want)
+	}
+}
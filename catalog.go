@@ -0,0 +1,96 @@
+package blokus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// standardPieces are the 21 polyominoes of the standard set, by their conventional names.
+// The letter is the shape the piece resembles and the number is how many squares it has.
+var standardPieces = []struct {
+	name   string
+	blocks []Coord
+}{
+	{"I1", []Coord{{0, 0}}},
+	{"I2", []Coord{{0, 0}, {1, 0}}},
+	{"I3", []Coord{{0, 0}, {1, 0}, {2, 0}}},
+	{"V3", []Coord{{0, 0}, {1, 0}, {1, 1}}},
+	{"I4", []Coord{{0, 0}, {1, 0}, {2, 0}, {3, 0}}},
+	{"L4", []Coord{{0, 0}, {1, 0}, {2, 0}, {2, 1}}},
+	{"T4", []Coord{{0, 0}, {1, 0}, {2, 0}, {1, 1}}},
+	{"O4", []Coord{{0, 0}, {1, 0}, {1, 1}, {0, 1}}},
+	{"Z4", []Coord{{0, 0}, {1, 0}, {1, 1}, {2, 1}}},
+	{"I5", []Coord{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}}},
+	{"L5", []Coord{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {3, 1}}},
+	{"Y5", []Coord{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {2, 1}}},
+	{"N5", []Coord{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {3, 1}}},
+	{"P5", []Coord{{0, 0}, {1, 0}, {2, 0}, {1, 1}, {2, 1}}},
+	{"U5", []Coord{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {2, 1}}},
+	{"V5", []Coord{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, 2}}},
+	{"W5", []Coord{{0, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}}},
+	{"Z5", []Coord{{0, 0}, {1, 0}, {1, 1}, {1, 2}, {2, 2}}},
+	{"T5", []Coord{{0, 0}, {1, 0}, {2, 0}, {2, 1}, {2, -1}}},
+	{"F5", []Coord{{0, 0}, {1, 0}, {1, 1}, {1, 2}, {2, 1}}},
+	{"X5", []Coord{{0, 0}, {1, 0}, {2, 0}, {1, 1}, {1, -1}}},
+}
+
+// StandardPieceNames returns the conventional names of the pieces in the standard set,
+// ordered by size, e.g. "I1", "I2", ..., "X5".
+func StandardPieceNames() []string {
+	names := make([]string, 0, len(standardPieces))
+	for _, sp := range standardPieces {
+		names = append(names, sp.name)
+	}
+	return names
+}
+
+// NewStandardPiece creates the piece of the standard set with the name. Names are case insensitive.
+func NewStandardPiece(name string) (*Piece, error) {
+	for _, sp := range standardPieces {
+		if strings.EqualFold(sp.name, name) {
+			p, err := NewPiece(sp.blocks)
+			if err != nil {
+				return nil, err
+			}
+			p.Name = sp.name
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("Unknown piece name: %v", name)
+}
+
+// StandardName returns the conventional name of the piece's shape, regardless of its orientation,
+// or an empty string if the shape is not in the standard set.
+func (p *Piece) StandardName() string {
+	canonical := p.Canonical()
+	for _, sp := range standardPieces {
+		if len(sp.blocks) == len(canonical) && equalCoords((&Piece{Blocks: sp.blocks}).Canonical(), canonical) {
+			return sp.name
+		}
+	}
+	return ""
+}
+
+// Size returns the number of squares of the piece.
+func (p *Piece) Size() int {
+	return len(p.Blocks)
+}
+
+// PieceIndex returns the index of the game's piece with the name. Names are case insensitive.
+func (g *Game) PieceIndex(name string) (int, error) {
+	for i, p := range g.Pieces {
+		if p != nil && len(p.Name) > 0 && strings.EqualFold(p.Name, name) {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("No piece named %v", name)
+}
+
+// PieceName returns the name of the game's piece at the index, or its index if the piece has no name.
+func (g *Game) PieceName(index int) string {
+	if index >= 0 && index < len(g.Pieces) && g.Pieces[index] != nil && len(g.Pieces[index].Name) > 0 {
+		return g.Pieces[index].Name
+	}
+	return strconv.Itoa(index)
+}
@@ -0,0 +1,99 @@
+package blokus
+
+import (
+	"testing"
+)
+
+func TestDefaultPieceNames(t *testing.T) {
+	want := []string{
+		"I1", "I2", "I3", "V3", "I4", "L4", "T4", "O4", "Z4",
+		"I5", "L5", "N5", "P5", "U5", "Y5", "T5", "V5", "W5", "Z5", "F5", "X5",
+	}
+	pieces := DefaultPieces()
+	if got := len(pieces); got != len(want) {
+		t.Fatalf("DefaultPieces(): got %d pieces, want %d", got, len(want))
+	}
+	for i, p := range pieces {
+		if got := p.Name; got != want[i] {
+			t.Errorf("DefaultPieces()[%d].Name: got %q, want %q", i, got, want[i])
+		}
+	}
+}
+
+func TestStandardPieces(t *testing.T) {
+	names := StandardPieceNames()
+	if got, want := len(names), 21; got != want {
+		t.Fatalf("StandardPieceNames(): got %d names, want %d", got, want)
+	}
+	total := 0
+	for i, name := range names {
+		p, err := NewStandardPiece(name)
+		if err != nil {
+			t.Fatalf("NewStandardPiece(%q): got error %v, want no error", name, err)
+		}
+		if got, want := p.Size(), int(name[1]-'0'); got != want {
+			t.Errorf("NewStandardPiece(%q).Size(): got %d, want %d", name, got, want)
+		}
+		if got := p.StandardName(); got != name {
+			t.Errorf("NewStandardPiece(%q).StandardName(): got %q, want %q", name, got, name)
+		}
+		for _, other := range names[:i] {
+			if o, _ := NewStandardPiece(other); o.SameShape(p) {
+				t.Errorf("Pieces %v and %v: got same shape, want different shapes", other, name)
+			}
+		}
+		total += p.Size()
+	}
+	if got, want := total, 89; got != want {
+		t.Errorf("Total squares of standard pieces: got %d, want %d", got, want)
+	}
+}
+
+func TestNewStandardPiece(t *testing.T) {
+	p, err := NewStandardPiece("f5")
+	if err != nil {
+		t.Fatalf("NewStandardPiece(f5): got error %v, want no error", err)
+	}
+	if got, want := p.Name, "F5"; got != want {
+		t.Errorf("NewStandardPiece(f5).Name: got %q, want %q", got, want)
+	}
+	if _, err := NewStandardPiece("Q7"); err == nil {
+		t.Error("NewStandardPiece(Q7): got no error, want error")
+	}
+}
+
+func TestStandardNameOfOrientedPiece(t *testing.T) {
+	// An L4 turned on its side.
+	p := newPieceOrDie(t, []Coord{{0, 0}, {0, 1}, {0, 2}, {1, 0}})
+	if got, want := p.StandardName(), "L4"; got != want {
+		t.Errorf("StandardName(): got %q, want %q", got, want)
+	}
+	// A hexomino is not in the standard set.
+	p = newPieceOrDie(t, []Coord{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}, {5, 0}})
+	if got, want := p.StandardName(), ""; got != want {
+		t.Errorf("StandardName(): got %q, want %q", got, want)
+	}
+}
+
+func TestPieceIndex(t *testing.T) {
+	g, err := NewGame(DefaultBoardSize, DefaultPieces())
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	i, err := g.PieceIndex("x5")
+	if err != nil {
+		t.Fatalf("PieceIndex(x5): got error %v, want no error", err)
+	}
+	if got, want := i, 20; got != want {
+		t.Errorf("PieceIndex(x5): got %d, want %d", got, want)
+	}
+	if _, err := g.PieceIndex("Q7"); err == nil {
+		t.Error("PieceIndex(Q7): got no error, want error")
+	}
+	if got, want := g.PieceName(19), "F5"; got != want {
+		t.Errorf("PieceName(19): got %q, want %q", got, want)
+	}
+	if got, want := g.PieceName(21), "21"; got != want {
+		t.Errorf("PieceName(21): got %q, want %q", got, want)
+	}
+}
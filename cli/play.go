@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -9,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/hueich/blokus"
+	"github.com/hueich/blokus/bot"
 )
 
 func getColorAsciiCode(c blokus.Color) int {
@@ -21,6 +23,10 @@ func getColorAsciiCode(c blokus.Color) int {
 		return 31
 	case blokus.Green:
 		return 32
+	case blokus.Purple:
+		return 35
+	case blokus.Orange:
+		return 91
 	default:
 		return 0
 	}
@@ -50,12 +56,50 @@ func renderBoard(b *blokus.Board) {
 	}
 }
 
+func renderStandings(g *blokus.Game) error {
+	standings, err := g.Standings()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Final standings:")
+	for _, s := range standings {
+		tie := ""
+		if s.Tied {
+			tie = " (tied)"
+		}
+		fmt.Printf("%d. %s with %d points%s\n", s.Rank, highlightString(s.Participant), s.Total, tie)
+		for _, sc := range s.Scores {
+			fmt.Printf("   %v: %d points, %d squares remaining\n", sc.Player.Color, sc.Total, sc.RemainingSquares)
+		}
+	}
+	winners, err := g.Winners()
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(winners))
+	for _, w := range winners {
+		names = append(names, highlightString(w))
+	}
+	fmt.Printf("%s won! Yay!\n", strings.Join(names, " and "))
+	return nil
+}
+
 func fscanln(r *bufio.Reader, a ...interface{}) error {
 	r.Discard(r.Buffered())
 	_, err := fmt.Fscanln(r, a...)
 	return err
 }
 
+// readLine reads a whole line of input, discarding anything typed before the prompt.
+func readLine(r *bufio.Reader) (string, error) {
+	r.Discard(r.Buffered())
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
 func promptForNewPlayers(g *blokus.Game) error {
 	stdin := bufio.NewReader(os.Stdin)
 	numPlayers := 0
@@ -110,6 +154,107 @@ func promptForNewPlayers(g *blokus.Game) error {
 	return nil
 }
 
+func promptForVariant() (*blokus.Variant, error) {
+	stdin := bufio.NewReader(os.Stdin)
+	var input string
+	for true {
+		fmt.Print("Which rules do you want to play? [classic/two/three/duo]: ")
+		if err := fscanln(stdin, &input); err != nil {
+			fmt.Println("Sorry, I didn't understand that.")
+			continue
+		}
+		input = strings.TrimSpace(input)
+		if strings.ToLower(input) == "classic" {
+			return nil, nil
+		}
+		v, err := blokus.LookupVariant(input)
+		if err != nil {
+			fmt.Printf("Sorry, I don't know those rules. %v\n", err)
+			continue
+		}
+		return v, nil
+	}
+	return nil, nil
+}
+
+func promptForVariantGame(v *blokus.Variant) (*blokus.Game, error) {
+	stdin := bufio.NewReader(os.Stdin)
+	for true {
+		names := make([]string, 0, v.NumPlayers())
+		for len(names) < v.NumPlayers() {
+			var name string
+			fmt.Printf("Enter name of player %d: ", len(names)+1)
+			if err := fscanln(stdin, &name); err != nil {
+				fmt.Println("Sorry, I didn't catch the name.")
+				continue
+			}
+			name = strings.TrimSpace(name)
+			if name == "" {
+				fmt.Println("Sorry, the name can't be empty.")
+				continue
+			}
+			names = append(names, name)
+		}
+		g, err := v.NewGame(blokus.DefaultPieces(), names)
+		if err != nil {
+			fmt.Printf("Sorry, I couldn't set up the game. %v\n", err)
+			continue
+		}
+		for _, p := range g.Players {
+			if p.IsShared() {
+				fmt.Printf("Color %v is shared by all players in turn and will start at coordinate %v\n", p.Color, p.StartPos)
+				continue
+			}
+			fmt.Printf("Player %s is color %v and will start at coordinate %v\n", highlightString(p.Name), p.Color, p.StartPos)
+		}
+		return g, nil
+	}
+	return nil, nil
+}
+
+func promptForBots(g *blokus.Game) (map[string]bot.Bot, error) {
+	stdin := bufio.NewReader(os.Stdin)
+	bots := map[string]bot.Bot{}
+	for _, name := range g.Participants() {
+		for true {
+			fmt.Printf("Should the computer play for %s? [no/random/greedy/mcts/maxn/paranoid]: ", highlightString(name))
+			input, err := readLine(stdin)
+			if err != nil {
+				fmt.Println("Sorry, I didn't understand that.")
+				continue
+			}
+			if input == "" || strings.ToLower(input) == "no" {
+				break
+			}
+			b, err := bot.Lookup(input)
+			if err != nil {
+				fmt.Printf("Sorry, I don't know that computer player. %v\n", err)
+				continue
+			}
+			bots[name] = b
+			break
+		}
+	}
+	return bots, nil
+}
+
+func playBotMove(g *blokus.Game, b bot.Bot) error {
+	name := g.CurrentPlayer().Name
+	r, err := bot.Play(context.Background(), g, b)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Computer player %s played %s.\n", highlightString(name), g.FormatMove(*r.Move))
+	renderTurnResult(r)
+	return nil
+}
+
+func renderTurnResult(r blokus.TurnResult) {
+	for _, p := range r.StatusChanges {
+		fmt.Printf("Player %s is done: %v.\n", highlightString(p.Name), p.Status)
+	}
+}
+
 func promptForNextMove(g *blokus.Game) error {
 	stdin := bufio.NewReader(os.Stdin)
 	player := g.CurrentPlayer()
@@ -118,26 +263,69 @@ func promptForNextMove(g *blokus.Game) error {
 
 	var input string
 	for true {
-		fmt.Printf("It's player %s's turn. Which piece do you want to play? (Type 'pass' to pass your turn): ", highlightString(player.Name))
-		if err := fscanln(stdin, &input); err != nil {
+		if player.IsShared() {
+			fmt.Printf("It's %s's turn to play the shared %v color. ", highlightString(g.Controller(player)), player.Color)
+		}
+		fmt.Printf("It's player %s's turn. Which piece do you want to play? Enter a piece name or number, or a whole move like 'F5 r1f @ 4,7'. (Type 'pass' to pass your turn, or 'undo'/'redo' to take back moves): ", highlightString(player.Name))
+		var err error
+		if input, err = readLine(stdin); err != nil || len(input) == 0 {
 			fmt.Println("Sorry, I didn't understand that.")
 			continue
 		}
 
-		input = strings.TrimSpace(input)
+		if len(strings.Fields(input)) > 1 {
+			m, err := g.ParseMove(input)
+			if err != nil {
+				fmt.Printf("Sorry, I couldn't understand that move. %v\n", err)
+				continue
+			}
+			r, err := g.Play(m)
+			if err != nil {
+				fmt.Printf("Sorry, I couldn't play that move. %v\n", err)
+				continue
+			}
+			fmt.Printf("Player %s played %s.\n", highlightString(player.Name), g.FormatMove(m))
+			renderTurnResult(r)
+			break
+		}
 		if strings.ToLower(input) == "pass" {
-			if err := g.PassTurn(player); err != nil {
+			r, err := g.Play(blokus.Move{Player: player, PieceIndex: -1})
+			if err != nil {
 				fmt.Printf("Sorry, I couldn't pass the player's turn. %v\n", err)
 				continue
 			}
 			fmt.Printf("Passing %s's turn.\n", highlightString(player.Name))
+			renderTurnResult(r)
 			break
 		}
 
-		i, err := strconv.Atoi(input)
+		if strings.ToLower(input) == "undo" {
+			m, err := g.Undo()
+			if err != nil {
+				fmt.Printf("Sorry, I couldn't undo the last move. %v\n", err)
+				continue
+			}
+			fmt.Printf("Took back %s's last move.\n", highlightString(m.Player.Name))
+			break
+		}
+		if strings.ToLower(input) == "redo" {
+			r, err := g.Redo()
+			if err != nil {
+				fmt.Printf("Sorry, I couldn't redo the move. %v\n", err)
+				continue
+			}
+			fmt.Printf("Replayed %s's move.\n", highlightString(r.Move.Player.Name))
+			renderTurnResult(r)
+			break
+		}
+
+		// Pieces can be chosen by name, e.g. 'F5', or by number.
+		i, err := g.PieceIndex(input)
 		if err != nil {
-			fmt.Println("Sorry, I couldn't understand the piece number.")
-			continue
+			if i, err = strconv.Atoi(input); err != nil {
+				fmt.Println("Sorry, I couldn't understand the piece name or number.")
+				continue
+			}
 		}
 		if err := player.CheckPiecePlaceability(i); err != nil {
 			fmt.Printf("Sorry, I can't place that piece. %v\n", err)
@@ -164,11 +352,13 @@ func promptForNextMove(g *blokus.Game) error {
 			continue
 		}
 
-		if err := g.PlacePiece(player, i, o, c); err != nil {
+		r, err := g.Play(blokus.Move{Player: player, PieceIndex: i, Orient: o, Loc: c})
+		if err != nil {
 			fmt.Printf("Sorry, I couldn't place that piece. %v\n", err)
 			continue
 		}
-		fmt.Printf("Player %s has placed piece %d.\n", highlightString(player.Name), i)
+		fmt.Printf("Player %s has placed piece %s.\n", highlightString(player.Name), g.PieceName(i))
+		renderTurnResult(r)
 		break
 	}
 	return nil
@@ -177,26 +367,45 @@ func promptForNextMove(g *blokus.Game) error {
 func main() {
 	fmt.Println("Welcome to the game!")
 
-	g, err := blokus.NewGame(blokus.DefaultBoardSize, blokus.DefaultPieces())
+	v, err := promptForVariant()
 	if err != nil {
-		log.Fatalf("Could not create new game: %v\n", err)
+		log.Fatal(err.Error())
 	}
 
-	if err := promptForNewPlayers(g); err != nil {
+	var g *blokus.Game
+	if v != nil {
+		if g, err = promptForVariantGame(v); err != nil {
+			log.Fatalf("Could not create new game: %v\n", err)
+		}
+	} else {
+		if g, err = blokus.NewGame(blokus.DefaultBoardSize, blokus.DefaultPieces()); err != nil {
+			log.Fatalf("Could not create new game: %v\n", err)
+		}
+		if err := promptForNewPlayers(g); err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
+	bots, err := promptForBots(g)
+	if err != nil {
 		log.Fatal(err.Error())
 	}
 
 	for !g.IsGameEnd() {
 		renderBoard(g.Board)
+		if b, ok := bots[g.CurrentController()]; ok {
+			if err := playBotMove(g, b); err != nil {
+				log.Fatalf("Could not play computer move: %v\n", err)
+			}
+			continue
+		}
 		if err := promptForNextMove(g); err != nil {
 			log.Fatalf("Could not process next move: %v\n", err)
 		}
-		if err := g.AdvanceTurn(); err != nil {
-			log.Fatalf("Could not advance turn: %v\n", err)
-		}
 	}
 
-	// TODO: Display score
-
-	fmt.Println("Someone won! Yay!")
+	renderBoard(g.Board)
+	if err := renderStandings(g); err != nil {
+		log.Fatalf("Could not calculate scores: %v\n", err)
+	}
 }
@@ -0,0 +1,172 @@
+package blokus
+
+// Clone returns a deep copy of the game, which can be changed without affecting this game.
+// Players, the board and moves are copied, with moves pointing to the copied players.
+// Pieces are shared, since they're not changed once the game is created.
+func (g *Game) Clone() *Game {
+	c := *g
+	if g.Board != nil {
+		c.Board = g.Board.Clone()
+	}
+	c.Pieces = append([]*Piece(nil), g.Pieces...)
+
+	players := make(map[*Player]*Player, len(g.Players))
+	c.Players = make([]*Player, 0, len(g.Players))
+	for _, p := range g.Players {
+		cp := p.clone()
+		players[p] = cp
+		c.Players = append(c.Players, cp)
+	}
+	c.Moves = cloneMoves(g.Moves, players)
+	c.Redos = cloneMoves(g.Redos, players)
+	if g.turnStates != nil {
+		c.turnStates = make(map[*Move]turnState, len(g.turnStates))
+		for i, m := range g.Moves {
+			if s, ok := g.turnStates[m]; ok {
+				c.turnStates[c.Moves[i]] = s
+			}
+		}
+	}
+	return &c
+}
+
+func (p *Player) clone() *Player {
+	c := *p
+	c.PlacedPieces = append([]bool(nil), p.PlacedPieces...)
+	if p.SharedBy != nil {
+		c.SharedBy = append([]string(nil), p.SharedBy...)
+	}
+	return &c
+}
+
+// cloneMoves copies the moves, pointing them to the copied players.
+// Players not in the map are left as they are.
+func cloneMoves(moves []*Move, players map[*Player]*Player) []*Move {
+	if moves == nil {
+		return nil
+	}
+	c := make([]*Move, 0, len(moves))
+	for _, m := range moves {
+		cm := *m
+		if p, ok := players[m.Player]; ok {
+			cm.Player = p
+		}
+		c = append(c, &cm)
+	}
+	return c
+}
+
+// Clone returns a deep copy of the board, including its cached bitboard and hash.
+func (b *Board) Clone() *Board {
+	c := *b
+	c.Grid = append([]Color(nil), b.Grid...)
+	if b.bits != nil {
+		c.bits = b.bits.clone()
+	}
+	return &c
+}
+
+func (bb *bitboard) clone() *bitboard {
+	c := *bb
+	c.occupied = append(bitset(nil), bb.occupied...)
+	for i := range bb.colors {
+		c.colors[i] = append(bitset(nil), bb.colors[i]...)
+		c.forbidden[i] = append(bitset(nil), bb.forbidden[i]...)
+		c.anchors[i] = append(bitset(nil), bb.anchors[i]...)
+	}
+	return &c
+}
+
+// Snapshot is a read-only view of a game at one point in time. It's unaffected by later changes to the game,
+// and can be read from several goroutines at once. Values returned by its methods are copies.
+type Snapshot struct {
+	game *Game
+}
+
+// Snapshot returns a read-only view of the current position of the game.
+func (g *Game) Snapshot() *Snapshot {
+	c := g.Clone()
+	if c.Board != nil {
+		// Fill in the caches now, since filling them later would write to the snapshot.
+		c.Board.bitboard()
+		c.Board.Hash()
+	}
+	return &Snapshot{game: c}
+}
+
+// Game returns a copy of the game at the time of the snapshot, e.g. for a bot to search from.
+func (s *Snapshot) Game() *Game {
+	return s.game.Clone()
+}
+
+// Board returns a copy of the board.
+func (s *Snapshot) Board() *Board {
+	return s.game.Board.Clone()
+}
+
+// Cell returns the color of the cell on the board.
+func (s *Snapshot) Cell(c Coord) Color {
+	if s.game.Board.IsOutOfBounds(c) || len(s.game.Board.Grid) == 0 {
+		return colorEmpty
+	}
+	return s.game.Board.Grid[c.X*s.game.Board.Width+c.Y]
+}
+
+// Pieces returns the pieces of the game. Pieces must not be changed.
+func (s *Snapshot) Pieces() []*Piece {
+	return append([]*Piece(nil), s.game.Pieces...)
+}
+
+// Players returns copies of the players in turn order.
+func (s *Snapshot) Players() []*Player {
+	return s.Game().Players
+}
+
+// CurrentPlayerIndex returns the index of the player whose turn it is.
+func (s *Snapshot) CurrentPlayerIndex() int {
+	return s.game.CurPlayerIndex
+}
+
+// Moves returns copies of the moves played, pointing to copies of the players.
+func (s *Snapshot) Moves() []Move {
+	g := s.Game()
+	moves := make([]Move, 0, len(g.Moves))
+	for _, m := range g.Moves {
+		moves = append(moves, *m)
+	}
+	return moves
+}
+
+// LegalMoves returns the legal moves of the player at the index, pointing to a copy of the player.
+// See Game.LegalMoves.
+func (s *Snapshot) LegalMoves(playerIndex int) []Move {
+	if playerIndex < 0 || playerIndex >= len(s.game.Players) {
+		return nil
+	}
+	moves := s.game.LegalMoves(s.game.Players[playerIndex])
+	player := s.game.Players[playerIndex].clone()
+	for i := range moves {
+		moves[i].Player = player
+	}
+	return moves
+}
+
+// IsGameEnd returns whether the game had ended. See Game.IsGameEnd.
+func (s *Snapshot) IsGameEnd() bool {
+	return s.game.IsGameEnd()
+}
+
+// Hash returns the hash of the position. See Game.Hash.
+func (s *Snapshot) Hash() uint64 {
+	return s.game.Hash()
+}
+
+// Scores returns the scores of the players. See Game.Scores.
+func (s *Snapshot) Scores() ([]*Score, error) {
+	return s.Game().Scores()
+}
+
+// FormatMove formats the move in move notation. See Game.FormatMove.
+func (s *Snapshot) FormatMove(m Move) string {
+	return s.game.FormatMove(m)
+}
@@ -0,0 +1,123 @@
+package blokus
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	g, err := ThreePlayerVariant().NewGame(DefaultPieces(), []string{"alice", "bob", "carol"})
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	playRandomly(t, g, 20)
+	if _, err := g.Undo(); err != nil {
+		t.Fatalf("Undo(): got %v, want no error", err)
+	}
+	grid := append([]Color(nil), g.Board.Grid...)
+	hash := g.Hash()
+	numMoves := len(g.Moves)
+
+	c := g.Clone()
+	if got, want := c.Hash(), hash; got != want {
+		t.Errorf("Hash() of clone: got %x, want %x", got, want)
+	}
+	for i, m := range c.Moves {
+		if got, want := c.playerIndex(m.Player), g.playerIndex(g.Moves[i].Player); got != want {
+			t.Errorf("Clone move %d player index: got %d, want %d", i, got, want)
+		}
+	}
+	for i, m := range c.Redos {
+		if c.playerIndex(m.Player) < 0 {
+			t.Errorf("Clone redo %d player: got player not in clone, want player in clone", i)
+		}
+	}
+	if !reflect.DeepEqual(c.Players, g.Players) {
+		t.Errorf("Clone players: got %v, want %v", c.Players, g.Players)
+	}
+	if c.Players[3].SharedBy[0] = "dave"; g.Players[3].SharedBy[0] == "dave" {
+		t.Error("Changing shared by of clone: got original changed, want original unchanged")
+	}
+
+	// Playing on in the clone leaves the original unchanged.
+	playRandomly(t, c, 20)
+	if err := c.Resign(c.Players[0]); err != nil {
+		t.Fatalf("Resign(): got %v, want no error", err)
+	}
+	if !reflect.DeepEqual(g.Board.Grid, grid) {
+		t.Error("Original board after playing in clone: got changed, want unchanged")
+	}
+	if got, want := len(g.Moves), numMoves; got != want {
+		t.Errorf("Original moves after playing in clone: got %d, want %d", got, want)
+	}
+	if got, want := g.Hash(), hash; got != want {
+		t.Errorf("Original hash after playing in clone: got %x, want %x", got, want)
+	}
+	if got, want := g.Players[0].Status, StatusActive; got != want {
+		t.Errorf("Original player status after resigning in clone: got %v, want %v", got, want)
+	}
+	if err := g.Verify(); err != nil {
+		t.Errorf("Verify() of original: got %v, want no error", err)
+	}
+	if err := c.Verify(); err != nil {
+		t.Errorf("Verify() of clone: got %v, want no error", err)
+	}
+	if got, want := len(c.LegalMoves(c.CurrentPlayer())), len(referenceLegalMoves(c, c.CurrentPlayer())); got != want {
+		t.Errorf("LegalMoves() count of clone: got %d, want %d", got, want)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	g := newMidGame(t, 12)
+	s := g.Snapshot()
+	hash := g.Hash()
+	cur := g.CurPlayerIndex
+	numLegal := len(g.LegalMoves(g.CurrentPlayer()))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.LegalMoves(s.CurrentPlayerIndex())
+			s.Hash()
+			s.IsGameEnd()
+			s.Scores()
+		}()
+	}
+	playRandomly(t, g, 10)
+	wg.Wait()
+
+	if got, want := s.Hash(), hash; got != want {
+		t.Errorf("Snapshot Hash() after game changed: got %x, want %x", got, want)
+	}
+	if got, want := s.CurrentPlayerIndex(), cur; got != want {
+		t.Errorf("Snapshot CurrentPlayerIndex(): got %d, want %d", got, want)
+	}
+	if got, want := len(s.Moves()), 12; got != want {
+		t.Errorf("Snapshot Moves() len: got %d, want %d", got, want)
+	}
+	moves := s.LegalMoves(cur)
+	if got, want := len(moves), numLegal; got != want {
+		t.Errorf("Snapshot LegalMoves() count: got %d, want %d", got, want)
+	}
+
+	// Changing returned values doesn't change the snapshot.
+	moves[0].Player.PlacedPieces[moves[0].PieceIndex] = true
+	s.Players()[cur].Status = StatusResigned
+	s.Board().SetCell(moves[0].Loc, Red)
+	sg := s.Game()
+	if _, err := sg.Play(moves[0]); err == nil {
+		t.Error("Play() in game from snapshot with move of another game's player: got no error, want error")
+	}
+	if got, want := len(s.LegalMoves(cur)), numLegal; got != want {
+		t.Errorf("Snapshot LegalMoves() count after changing returned values: got %d, want %d", got, want)
+	}
+	if got, want := s.Hash(), hash; got != want {
+		t.Errorf("Snapshot Hash() after changing returned values: got %x, want %x", got, want)
+	}
+	if got := s.Cell(Coord{-1, 0}); got != colorEmpty {
+		t.Errorf("Snapshot Cell() out of bounds: got %v, want %v", got, colorEmpty)
+	}
+}
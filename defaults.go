@@ -150,5 +150,8 @@ func DefaultPieces() []*Piece {
 		{1, -1},
 	}))
 
+	for _, p := range pieces {
+		p.Name = p.StandardName()
+	}
 	return pieces
 }
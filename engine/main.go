@@ -0,0 +1,31 @@
+// Command engine runs a built-in bot as an engine, speaking the engine protocol on stdin and stdout,
+// so it can play anywhere external engines can, e.g. against bots written in other languages.
+//
+//	engine -bot mcts
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/hueich/blokus/bot"
+)
+
+func main() {
+	kind := flag.String("bot", "greedy", "Kind of bot to run: random, greedy, mcts, maxn or paranoid")
+	name := flag.String("name", "", "Name the engine gives itself. Defaults to the kind of bot")
+	flag.Parse()
+
+	b, err := bot.Lookup(*kind)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+	if len(*name) == 0 {
+		*name = *kind
+	}
+	if err := bot.Serve(context.Background(), b, *name, os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err.Error())
+	}
+}
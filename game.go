@@ -2,6 +2,7 @@ package blokus
 
 import (
 	"fmt"
+	"strings"
 )
 
 const (
@@ -15,6 +16,12 @@ var (
 		{0, -1},
 		{0, 1},
 	}
+	diagonals = [4]Coord{
+		{-1, -1},
+		{-1, 1},
+		{1, -1},
+		{1, 1},
+	}
 )
 
 type Game struct {
@@ -24,8 +31,39 @@ type Game struct {
 	Pieces []*Piece
 	// Index of the player whose turn it is.
 	CurPlayerIndex int
+	// Index of the player who moved first.
+	FirstPlayerIndex int
+	// Variant is the name of the rules the game was set up with, if any.
+	Variant string
 	// Moves that have been played.
 	Moves []*Move
+	// ForbidVoluntaryPass makes Play reject passing while the player still has a legal move.
+	ForbidVoluntaryPass bool
+	// Moves that have been taken back by Undo, with the most recently undone last.
+	Redos []*Move
+	// UndoPolicy decides whether a move can be taken back. Any move can be undone if nil.
+	UndoPolicy UndoPolicy `datastore:"-"`
+	// States before the moves made by Play, to restore them on Undo. Moves without one,
+	// e.g. in a game loaded from datastore, are undone by replaying the moves before them.
+	turnStates map[*Move]turnState
+}
+
+// turnState is the part of the position before a move that can't be worked out from the move itself.
+type turnState struct {
+	statuses []PlayerStatus
+	cur      int
+}
+
+// TurnResult describes what changed in the game after a turn was played.
+type TurnResult struct {
+	// Move is the move that was recorded.
+	Move *Move
+	// NextPlayer is the player whose turn it is now. Nil if the game ended.
+	NextPlayer *Player
+	// StatusChanges are the players whose status changed during the turn, e.g. by placing all pieces or running out of moves.
+	StatusChanges []*Player
+	// GameEnded is whether the game ended with this turn.
+	GameEnded bool
 }
 
 func NewGame(size int, pieces []*Piece) (*Game, error) {
@@ -35,6 +73,12 @@ func NewGame(size int, pieces []*Piece) (*Game, error) {
 	if len(pieces) == 0 {
 		return nil, fmt.Errorf("Cannot create game with no pieces")
 	}
+	if err := ValidatePieces(pieces); err != nil {
+		return nil, err
+	}
+	for _, p := range pieces {
+		p.warmCaches()
+	}
 	b, err := NewBoard(size)
 	if err != nil {
 		return nil, fmt.Errorf("Could not create game board: %v", err)
@@ -49,6 +93,7 @@ func (g *Game) CurrentPlayer() *Player {
 	return g.Players[g.CurPlayerIndex]
 }
 
+// GetNextFreeColor returns the first of the four classic colors that no player has.
 func (g *Game) GetNextFreeColor() (Color, error) {
 	allColors := make([]bool, int(colorEnd))
 	for _, p := range g.Players {
@@ -57,7 +102,7 @@ func (g *Game) GetNextFreeColor() (Color, error) {
 		}
 		allColors[int(p.Color)] = true
 	}
-	for i := 1; i < int(colorEnd); i++ {
+	for i := int(Blue); i <= int(Green); i++ {
 		if !allColors[i] {
 			return Color(i), nil
 		}
@@ -65,41 +110,104 @@ func (g *Game) GetNextFreeColor() (Color, error) {
 	return 0, fmt.Errorf("No more free colors")
 }
 
+// GetNextFreeCorner returns the first corner of the board that isn't a player's starting position,
+// going clockwise from the top left.
+func (g *Game) GetNextFreeCorner() (Coord, error) {
+	corners := g.freeCorners()
+	if len(corners) == 0 {
+		return Coord{}, fmt.Errorf("No more free corners")
+	}
+	return corners[0], nil
+}
+
+// freeCorners returns the corners of the board that aren't a player's starting position,
+// going clockwise from the top left.
+func (g *Game) freeCorners() []Coord {
+	corners := []Coord{
+		{0, 0},
+		{0, g.Board.Width - 1},
+		{g.Board.Height - 1, g.Board.Width - 1},
+		{g.Board.Height - 1, 0},
+	}
+	var free []Coord
+	for _, c := range corners {
+		taken := false
+		for _, p := range g.Players {
+			if p.StartPos == c {
+				taken = true
+				break
+			}
+		}
+		if !taken {
+			free = append(free, c)
+		}
+	}
+	return free
+}
+
+// AddPlayer adds a player to the game. See checkName for which names are allowed.
 func (g *Game) AddPlayer(name string, color Color, startPos Coord) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	_, err := g.addPlayer(name, color, startPos)
+	return err
+}
+
+// sharedNamePrefix starts the names of shared colors, e.g. "shared green", so people can't use it.
+const sharedNamePrefix = "shared "
+
+// checkName returns an error if the name can't be a person's name. Names are listed with ", "
+// in game records, so they can't contain commas, and names of shared colors are reserved.
+func checkName(name string) error {
 	if len(name) == 0 {
 		return fmt.Errorf("Player name cannot be empty")
 	}
+	if strings.Contains(name, ",") {
+		return fmt.Errorf("Player name cannot contain ',': %q", name)
+	}
+	if strings.HasPrefix(name, sharedNamePrefix) {
+		return fmt.Errorf("Player name cannot start with %q, which is kept for shared colors: %q", sharedNamePrefix, name)
+	}
+	return nil
+}
+
+func (g *Game) addPlayer(name string, color Color, startPos Coord) (*Player, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("Player name cannot be empty")
+	}
 	if color == colorEmpty {
 		var err error
 		color, err = g.GetNextFreeColor()
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 	if !color.IsColored() {
-		return fmt.Errorf("Invalid color %v", color)
+		return nil, fmt.Errorf("Invalid color %v", color)
 	}
 	if g.Board.IsOutOfBounds(startPos) {
-		return fmt.Errorf("Starting position is out of bounds: %v", startPos)
+		return nil, fmt.Errorf("Starting position is out of bounds: %v", startPos)
 	}
 
 	for _, p := range g.Players {
-		if p.Name == name {
-			return fmt.Errorf("Player %v already in the game", name)
+		// A person playing several colors is known by the participant name, not the players' names.
+		if p.Name == name || (!p.IsShared() && p.Participant() == name) {
+			return nil, fmt.Errorf("Player %v already in the game", name)
 		}
 		if p.Color == color {
-			return fmt.Errorf("Color %v already taken by player %v", color, p.Name)
+			return nil, fmt.Errorf("Color %v already taken by player %v", color, p.Name)
 		}
 		if p.StartPos == startPos {
-			return fmt.Errorf("Starting position already occupied by player %v", p.Name)
+			return nil, fmt.Errorf("Starting position already occupied by player %v", p.Name)
 		}
 	}
 	p, err := NewPlayer(name, color, startPos, len(g.Pieces))
 	if err != nil {
-		return fmt.Errorf("Error adding new player: %v", err)
+		return nil, fmt.Errorf("Error adding new player: %v", err)
 	}
 	g.Players = append(g.Players, p)
-	return nil
+	return p, nil
 }
 
 func (g *Game) PassTurn(player *Player) error {
@@ -110,7 +218,11 @@ func (g *Game) PassTurn(player *Player) error {
 	if player != g.Players[g.CurPlayerIndex] {
 		return fmt.Errorf("Turn belongs to player %v, not player %v", g.Players[g.CurPlayerIndex].Name, player.Name)
 	}
-	// Record the move.
+	if !player.Status.IsActive() {
+		return fmt.Errorf("Player %v is no longer active: %v", player.Name, player.Status)
+	}
+	// Record the move. It replaces whatever was undone before.
+	g.Redos = nil
 	g.Moves = append(g.Moves, &Move{
 		Player:     player,
 		PieceIndex: -1,
@@ -128,6 +240,9 @@ func (g *Game) PlacePiece(player *Player, pieceIndex int, orient Orientation, lo
 	if player != g.Players[g.CurPlayerIndex] {
 		return fmt.Errorf("Turn belongs to player %v, not player %v", g.Players[g.CurPlayerIndex].Name, player.Name)
 	}
+	if !player.Status.IsActive() {
+		return fmt.Errorf("Player %v is no longer active: %v", player.Name, player.Status)
+	}
 
 	if pieceIndex < 0 || pieceIndex >= len(g.Pieces) {
 		return fmt.Errorf("Piece index is out of range: %d", pieceIndex)
@@ -156,8 +271,12 @@ func (g *Game) PlacePiece(player *Player, pieceIndex int, orient Orientation, lo
 	for _, b := range orientedPiece.Blocks {
 		g.Board.SetCell(Coord{loc.X + b.X, loc.Y + b.Y}, player.Color)
 	}
+	if player.HasPlacedAllPieces() {
+		player.Status = StatusAllPlaced
+	}
 
-	// Record the move.
+	// Record the move. It replaces whatever was undone before.
+	g.Redos = nil
 	g.Moves = append(g.Moves, &Move{
 		Player:     player,
 		PieceIndex: pieceIndex,
@@ -168,6 +287,63 @@ func (g *Game) PlacePiece(player *Player, pieceIndex int, orient Orientation, lo
 	return nil
 }
 
+// Play validates and applies the move for the current player, then advances the turn to the next active player.
+// The move is a pass if its piece index is negative, in which case the orientation and location are ignored.
+// A resignation may be made by any active player, and only advances the turn if it was the player's turn.
+func (g *Game) Play(m Move) (TurnResult, error) {
+	if len(g.Players) == 0 {
+		return TurnResult{}, fmt.Errorf("Cannot play a turn with no players")
+	}
+	if g.IsGameEnd() {
+		return TurnResult{}, fmt.Errorf("Game has already ended")
+	}
+	before := g.turnState()
+
+	advance := true
+	switch {
+	case m.Resign:
+		advance = m.Player == g.CurrentPlayer()
+		if err := g.Resign(m.Player); err != nil {
+			return TurnResult{}, err
+		}
+	case m.IsPass():
+		// Out of turn passes are left to PassTurn, to fail for the turn rather than the legal moves.
+		if g.ForbidVoluntaryPass && m.Player == g.CurrentPlayer() && g.HasLegalMove(m.Player) {
+			return TurnResult{}, fmt.Errorf("Player %v cannot pass while having a legal move", m.Player.Name)
+		}
+		if err := g.PassTurn(m.Player); err != nil {
+			return TurnResult{}, err
+		}
+	default:
+		if err := g.PlacePiece(m.Player, m.PieceIndex, m.Orient, m.Loc); err != nil {
+			return TurnResult{}, err
+		}
+	}
+	if advance {
+		if err := g.AdvanceTurn(); err != nil {
+			return TurnResult{}, err
+		}
+	}
+
+	r := TurnResult{
+		Move:      g.Moves[len(g.Moves)-1],
+		GameEnded: g.IsGameEnd(),
+	}
+	if g.turnStates == nil {
+		g.turnStates = map[*Move]turnState{}
+	}
+	g.turnStates[r.Move] = before
+	if !r.GameEnded {
+		r.NextPlayer = g.CurrentPlayer()
+	}
+	for i, p := range g.Players {
+		if p.Status != before.statuses[i] {
+			r.StatusChanges = append(r.StatusChanges, p)
+		}
+	}
+	return r, nil
+}
+
 // Checks whether piece placement is valid. Returns error if invalid.
 // The piece should already be oriented.
 func (g *Game) checkPiecePlacement(player *Player, piece *Piece, loc Coord) error {
@@ -225,25 +401,80 @@ func (g *Game) checkPiecePlacement(player *Player, piece *Piece, loc Coord) erro
 	return nil
 }
 
-// Advances the game turn to the next player.
+// Resign takes the player out of the game, and records it as a move. The player keeps the pieces placed so far,
+// but won't get any more turns. Players may resign at any time, not only on their turn.
+// This does not advance player turn.
+func (g *Game) Resign(player *Player) error {
+	if player == nil || g.playerIndex(player) < 0 {
+		return fmt.Errorf("Invalid player")
+	}
+	if !player.Status.IsActive() {
+		return fmt.Errorf("Player %v is no longer active: %v", player.Name, player.Status)
+	}
+	player.Status = StatusResigned
+	// Record the move. It replaces whatever was undone before.
+	g.Redos = nil
+	g.Moves = append(g.Moves, &Move{
+		Player:     player,
+		PieceIndex: -1,
+		Resign:     true,
+	})
+	return nil
+}
+
+// Advances the game turn to the next active player.
+// Players found to have no legal moves left are marked as out of moves and skipped.
+// If no active player remains, the turn stays with the current player.
 func (g *Game) AdvanceTurn() error {
 	if len(g.Players) == 0 {
 		return fmt.Errorf("Cannot advance turn with no players")
 	}
-	g.CurPlayerIndex = (g.CurPlayerIndex + 1) % len(g.Players)
+	for i := 1; i <= len(g.Players); i++ {
+		index := (g.CurPlayerIndex + i) % len(g.Players)
+		p := g.Players[index]
+		if p.Status.IsActive() && !g.HasLegalMove(p) {
+			p.Status = StatusOutOfMoves
+		}
+		if p.Status.IsActive() {
+			g.CurPlayerIndex = index
+			return nil
+		}
+	}
 	return nil
 }
 
-// Game ends when all players passed for a round.
+// ActivePlayers returns the players who still take turns.
+func (g *Game) ActivePlayers() []*Player {
+	var ps []*Player
+	for _, p := range g.Players {
+		if p.Status.IsActive() {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// Game ends when no player is active anymore, or when all active players passed for a round.
+// Shared colors alone don't keep the game going.
 func (g *Game) IsGameEnd() bool {
-	if len(g.Moves) == 0 || len(g.Moves) < len(g.Players) {
+	if len(g.Players) == 0 {
 		return false
 	}
-	for _, m := range g.Moves[len(g.Moves)-len(g.Players):] {
-		// TODO: Refine logic so players who finished early don't have to keep passing.
+	numActive := len(g.ActivePlayers())
+	if numActive == 0 || g.onlySharedActive() {
+		return true
+	}
+	// Resignations don't take a turn, so they don't break a round of passes.
+	passes := 0
+	for i := len(g.Moves) - 1; i >= 0 && passes < numActive; i-- {
+		m := g.Moves[i]
+		if m.Resign {
+			continue
+		}
 		if !m.IsPass() {
 			return false
 		}
+		passes++
 	}
-	return true
+	return passes >= numActive
 }
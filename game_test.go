@@ -23,7 +23,7 @@ func newPieceOrDie(t *testing.T, blocks []Coord) *Piece {
 }
 
 func TestNewGame(t *testing.T) {
-	p := newPieceOrDie(t, []Coord{Coord{3, 4}})
+	p := newPieceOrDie(t, []Coord{{0, 0}, {0, 1}})
 	g, err := NewGame(22, []*Piece{p})
 	if err != nil {
 		t.Fatalf("NewGame(): got %v, want no error", err)
@@ -41,18 +41,18 @@ func TestNewGame(t *testing.T) {
 	if got, want := len(g.Pieces), 1; got != want {
 		t.Fatalf("Game pieces len: got %v, want %v", got, want)
 	}
-	if got, want := len(g.Pieces[0].Blocks), 1; got != want {
+	if got, want := len(g.Pieces[0].Blocks), 2; got != want {
 		t.Fatalf("Game pieces[0] blocks len: got %v, want %v", got, want)
 	}
-	if got, want := g.Pieces[0].Blocks[0], (Coord{3, 4}); got != want {
-		t.Errorf("Game pieces[0] blocks[0]: got %v, want %v", got, want)
+	if got, want := g.Pieces[0].Blocks[1], (Coord{0, 1}); got != want {
+		t.Errorf("Game pieces[0] blocks[1]: got %v, want %v", got, want)
 	}
 }
 
 func TestAddPlayer(t *testing.T) {
 	ps := []*Piece{
-		newPieceOrDie(t, []Coord{{3, 4}}),
-		newPieceOrDie(t, []Coord{{5, 6}}),
+		newPieceOrDie(t, []Coord{{0, 0}}),
+		newPieceOrDie(t, []Coord{{0, 0}, {1, 0}}),
 	}
 	g, err := NewGame(22, ps)
 	if err != nil {
@@ -93,6 +93,28 @@ func TestAddPlayerInvalidColor(t *testing.T) {
 	}
 }
 
+func TestAddPlayerInvalidName(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{"", "empty"},
+		{"smith, john", "','"},
+		{"shared green", "shared colors"},
+	} {
+		g := newGameOrDie(t)
+		if err := g.AddPlayer(tc.name, Blue, Coord{0, 0}); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("AddPlayer(%q): got %v, want error containing %q", tc.name, err, tc.want)
+		}
+		if err := g.AddParticipantColors(tc.name, []Color{Blue, Red}); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("AddParticipantColors(%q): got %v, want error containing %q", tc.name, err, tc.want)
+		}
+		if err := g.AddSharedPlayer(Green, Coord{9, 0}, []string{"foo", tc.name}); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("AddSharedPlayer() shared by %q: got %v, want error containing %q", tc.name, err, tc.want)
+		}
+	}
+}
+
 func TestAddPlayerInvalidStartPosition(t *testing.T) {
 	g := newGameOrDie(t)
 	if err := g.AddPlayer("foo", Blue, Coord{10, 10}); err == nil || !strings.Contains(err.Error(), "position is out of bounds") {
@@ -150,7 +172,7 @@ func TestAddPlayerAutoAssignColor(t *testing.T) {
 
 func TestAddPlayerAutoAssignColorNoMoreColors(t *testing.T) {
 	g := newGameOrDie(t)
-	for i := 1; i < int(colorEnd); i++ {
+	for i := int(Blue); i <= int(Green); i++ {
 		if err := g.AddPlayer(fmt.Sprintf("foo_%d", i), colorEmpty, Coord{0, i}); err != nil {
 			t.Fatalf("Add player %d with no color: got error %v, want no error", i, err)
 		}
@@ -159,11 +181,15 @@ func TestAddPlayerAutoAssignColorNoMoreColors(t *testing.T) {
 	for _, p := range g.Players {
 		colors[p.Color] = true
 	}
-	if got, want := len(colors), int(colorEnd)-1; got != want {
+	if got, want := len(colors), 4; got != want {
 		t.Errorf("Num colors after adding max players: got %v, want %v", got, want)
 	}
-	if err := g.AddPlayer("bar", colorEmpty, Coord{1, 0}); err == nil || !strings.Contains(err.Error(), "color") {
-		t.Errorf("Add extra player with no color: got %v, want error about color", err)
+	// The Duo colors aren't handed out to a fifth player.
+	if err := g.AddPlayer("bar", colorEmpty, Coord{1, 0}); err == nil || !strings.Contains(err.Error(), "No more free colors") {
+		t.Errorf("Add extra player with no color: got %v, want no more free colors error", err)
+	}
+	if err := g.AddPlayer("baz", Purple, Coord{1, 0}); err != nil {
+		t.Errorf("Add extra player with purple: got %v, want no error", err)
 	}
 }
 
@@ -407,3 +433,165 @@ func TestIsGameEnd(t *testing.T) {
 		t.Errorf("IsGameEnd() with 2 passes: got %v, want true", got)
 	}
 }
+
+func TestPlacePieceAllPlaced(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddPlayer("foo", Blue, Coord{0, 0}); err != nil {
+		t.Fatalf("AddPlayer(foo): got %v, want no error", err)
+	}
+	if err := g.PlacePiece(g.Players[0], 0, Orientation{Rot0, false}, Coord{0, 0}); err != nil {
+		t.Fatalf("PlacePiece(): got %v, want no error", err)
+	}
+	if got, want := g.Players[0].Status, StatusAllPlaced; got != want {
+		t.Errorf("Player status after placing all pieces: got %v, want %v", got, want)
+	}
+	if got := g.IsGameEnd(); !got {
+		t.Errorf("IsGameEnd() with no active players: got %v, want true", got)
+	}
+}
+
+func TestResign(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	if err := g.Resign(g.Players[1]); err != nil {
+		t.Fatalf("Resign(player1): got %v, want no error", err)
+	}
+	if got, want := g.Players[1].Status, StatusResigned; got != want {
+		t.Errorf("Player status after Resign(): got %v, want %v", got, want)
+	}
+	if err := g.Resign(g.Players[1]); err == nil || !strings.Contains(err.Error(), "no longer active") {
+		t.Errorf("Resign(player1) again: got %v, want no longer active error", err)
+	}
+	// The turn stays with the only active player.
+	if err := g.AdvanceTurn(); err != nil {
+		t.Fatalf("AdvanceTurn(): got %v, want no error", err)
+	}
+	if got, want := g.CurPlayerIndex, 0; got != want {
+		t.Errorf("Player turn after AdvanceTurn(): got %v, want %v", got, want)
+	}
+	if err := g.Resign(g.Players[0]); err != nil {
+		t.Fatalf("Resign(player0): got %v, want no error", err)
+	}
+	if err := g.PassTurn(g.Players[0]); err == nil || !strings.Contains(err.Error(), "no longer active") {
+		t.Errorf("PassTurn() by resigned player: got %v, want no longer active error", err)
+	}
+	if got := g.IsGameEnd(); !got {
+		t.Errorf("IsGameEnd() with all players resigned: got %v, want true", got)
+	}
+}
+
+func TestAdvanceTurnSkipsPlayersOutOfMoves(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 5)
+	if err := g.AddPlayer("baz", Red, Coord{0, 4}); err != nil {
+		t.Fatalf("AddPlayer(baz): got %v, want no error", err)
+	}
+	// Red walls in yellow's starting corner, so none of yellow's pieces fit.
+	g.Board.SetCell(Coord{3, 3}, Red)
+	g.Board.SetCell(Coord{3, 4}, Red)
+	g.Board.SetCell(Coord{4, 3}, Red)
+	if err := g.AdvanceTurn(); err != nil {
+		t.Fatalf("AdvanceTurn(): got %v, want no error", err)
+	}
+	if got, want := g.Players[1].Status, StatusOutOfMoves; got != want {
+		t.Errorf("Yellow status: got %v, want %v", got, want)
+	}
+	if got, want := g.CurPlayerIndex, 2; got != want {
+		t.Errorf("Player turn after AdvanceTurn(): got %v, want %v", got, want)
+	}
+}
+
+func TestPlay(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	r, err := g.Play(Move{Player: g.Players[0], PieceIndex: 0, Orient: Orientation{Rot0, false}, Loc: Coord{0, 0}})
+	if err != nil {
+		t.Fatalf("Play(): got %v, want no error", err)
+	}
+	if got, want := len(g.Moves), 1; got != want {
+		t.Fatalf("Num moves after Play(): got %v, want %v", got, want)
+	}
+	if r.Move != g.Moves[0] {
+		t.Errorf("Play() result move: got %v, want %v", r.Move, g.Moves[0])
+	}
+	if r.NextPlayer != g.Players[1] {
+		t.Errorf("Play() result next player: got %v, want %v", r.NextPlayer, g.Players[1].Name)
+	}
+	if got, want := g.CurPlayerIndex, 1; got != want {
+		t.Errorf("Player turn after Play(): got %v, want %v", got, want)
+	}
+	if r.GameEnded || len(r.StatusChanges) != 0 {
+		t.Errorf("Play() result: got GameEnded=%v StatusChanges=%v, want no changes", r.GameEnded, r.StatusChanges)
+	}
+}
+
+func TestPlayInvalidMove(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	if _, err := g.Play(Move{Player: g.Players[0], PieceIndex: 0, Orient: Orientation{Rot0, false}, Loc: Coord{5, 5}}); err == nil {
+		t.Fatal("Play() with invalid placement: got no error, want error")
+	}
+	if got, want := g.CurPlayerIndex, 0; got != want {
+		t.Errorf("Player turn after failed Play(): got %v, want %v", got, want)
+	}
+	if got := len(g.Moves); got != 0 {
+		t.Errorf("Num moves after failed Play(): got %v, want 0", got)
+	}
+}
+
+func TestPlayForbidVoluntaryPass(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	g.ForbidVoluntaryPass = true
+	if _, err := g.Play(Move{Player: g.Players[0], PieceIndex: -1}); err == nil || !strings.Contains(err.Error(), "cannot pass") {
+		t.Errorf("Play(pass) with legal moves: got %v, want cannot pass error", err)
+	}
+	if _, err := g.Play(Move{Player: g.Players[1], PieceIndex: -1}); err == nil || !strings.Contains(err.Error(), "Turn belongs to") {
+		t.Errorf("Play(pass) out of turn: got %v, want turn error", err)
+	}
+	g.ForbidVoluntaryPass = false
+	if _, err := g.Play(Move{Player: g.Players[0], PieceIndex: -1}); err != nil {
+		t.Errorf("Play(pass) with voluntary passing allowed: got %v, want no error", err)
+	}
+}
+
+func TestPlayGameEnd(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddPlayer("foo", Blue, Coord{0, 0}); err != nil {
+		t.Fatalf("AddPlayer(foo): got %v, want no error", err)
+	}
+	r, err := g.Play(Move{Player: g.Players[0], PieceIndex: 0, Orient: Orientation{Rot0, false}, Loc: Coord{0, 0}})
+	if err != nil {
+		t.Fatalf("Play(): got %v, want no error", err)
+	}
+	if !r.GameEnded || r.NextPlayer != nil {
+		t.Errorf("Play() result: got GameEnded=%v NextPlayer=%v, want game ended with no next player", r.GameEnded, r.NextPlayer)
+	}
+	if len(r.StatusChanges) != 1 || r.StatusChanges[0] != g.Players[0] {
+		t.Errorf("Play() result status changes: got %v, want only player %v", r.StatusChanges, g.Players[0].Name)
+	}
+	if _, err := g.Play(Move{Player: g.Players[0], PieceIndex: -1}); err == nil || !strings.Contains(err.Error(), "ended") {
+		t.Errorf("Play() after game end: got %v, want game ended error", err)
+	}
+}
+
+func TestPlayNoPlayers(t *testing.T) {
+	g := newGameOrDie(t)
+	if _, err := g.Play(Move{PieceIndex: -1}); err == nil || !strings.Contains(err.Error(), "no players") {
+		t.Errorf("Play() with no players: got %v, want no players error", err)
+	}
+}
+
+func TestGetNextFreeCorner(t *testing.T) {
+	g := newGameOrDie(t)
+	for _, want := range []Coord{{0, 0}, {0, 9}, {9, 9}, {9, 0}} {
+		c, err := g.GetNextFreeCorner()
+		if err != nil {
+			t.Fatalf("GetNextFreeCorner(): got %v, want no error", err)
+		}
+		if c != want {
+			t.Errorf("GetNextFreeCorner(): got %v, want %v", c, want)
+		}
+		if err := g.AddPlayer(fmt.Sprintf("foo_%d_%d", want.X, want.Y), colorEmpty, c); err != nil {
+			t.Fatalf("AddPlayer(): got %v, want no error", err)
+		}
+	}
+	if _, err := g.GetNextFreeCorner(); err == nil {
+		t.Error("GetNextFreeCorner() with all corners taken: got no error, want error")
+	}
+}
@@ -0,0 +1,418 @@
+package blokus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// JSONVersion is the version of the JSON format of games. It's increased when the format changes
+	// in a way older readers can't handle.
+	JSONVersion = 1
+
+	// emptyCellLetter stands for an empty cell in the rows of a board.
+	emptyCellLetter = "."
+)
+
+// jsonGame is the JSON format of a game.
+type jsonGame struct {
+	Version             int           `json:"version"`
+	Variant             string        `json:"variant,omitempty"`
+	Board               *Board        `json:"board"`
+	Pieces              []*jsonPiece  `json:"pieces"`
+	Players             []*jsonPlayer `json:"players"`
+	CurPlayerIndex      int           `json:"currentPlayer"`
+	FirstPlayerIndex    int           `json:"firstPlayer"`
+	ForbidVoluntaryPass bool          `json:"forbidVoluntaryPass,omitempty"`
+	Moves               []*jsonMove   `json:"moves"`
+	Redos               []*jsonMove   `json:"redos,omitempty"`
+}
+
+// jsonBoard is the JSON format of a board, with one string per row and one letter per cell.
+type jsonBoard struct {
+	Height int      `json:"height"`
+	Width  int      `json:"width"`
+	Rows   []string `json:"rows"`
+}
+
+type jsonPiece struct {
+	Name   string   `json:"name,omitempty"`
+	Blocks [][2]int `json:"blocks"`
+}
+
+type jsonPlayer struct {
+	Name     string `json:"name"`
+	Color    Color  `json:"color"`
+	StartPos [2]int `json:"startPos"`
+	// NumPieces is the number of pieces the player has, placed or not.
+	NumPieces int `json:"numPieces"`
+	// Placed are the indexes of the placed pieces.
+	Placed   []int    `json:"placed"`
+	Status   string   `json:"status"`
+	SharedBy []string `json:"sharedBy,omitempty"`
+	Owner    string   `json:"owner,omitempty"`
+}
+
+// jsonMove is the JSON format of a move. Within a game, the player is given by its index.
+// On its own, the player is given by its color.
+type jsonMove struct {
+	Player *int   `json:"player,omitempty"`
+	Color  Color  `json:"color,omitempty"`
+	Piece  int    `json:"piece"`
+	Orient string `json:"orient,omitempty"`
+	Loc    [2]int `json:"loc"`
+	Resign bool   `json:"resign,omitempty"`
+}
+
+// MarshalJSON encodes the color as its name, e.g. "blue".
+func (c Color) MarshalJSON() ([]byte, error) {
+	if c != colorEmpty && !c.IsColored() {
+		return nil, fmt.Errorf("Invalid color: %d", c)
+	}
+	return json.Marshal(c.String())
+}
+
+// UnmarshalJSON decodes a color from its name, or from its number.
+func (c *Color) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		var n uint8
+		if err := json.Unmarshal(b, &n); err != nil {
+			return fmt.Errorf("Color must be a name or a number: %s", b)
+		}
+		name = strconv.Itoa(int(n))
+	}
+	parsed, err := ParseColor(name)
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// ParseColor returns the color with the name, e.g. "blue", or with the number.
+func ParseColor(s string) (Color, error) {
+	if n, err := strconv.Atoi(s); err == nil {
+		if c := Color(n); n >= 0 && (c == colorEmpty || c.IsColored()) {
+			return c, nil
+		}
+		return colorEmpty, fmt.Errorf("Invalid color: %v", s)
+	}
+	for c := colorEmpty; c < colorEnd; c++ {
+		if strings.EqualFold(c.String(), s) {
+			return c, nil
+		}
+	}
+	return colorEmpty, fmt.Errorf("Unknown color: %v", s)
+}
+
+func parsePlayerStatus(s string) (PlayerStatus, error) {
+	for st := StatusActive; st <= StatusAllPlaced; st++ {
+		if st.String() == s {
+			return st, nil
+		}
+	}
+	return StatusActive, fmt.Errorf("Unknown player status: %v", s)
+}
+
+// MarshalJSON encodes the board with its cells as rows of color letters, and "." for empty cells.
+func (b *Board) MarshalJSON() ([]byte, error) {
+	jb := jsonBoard{Height: b.Height, Width: b.Width, Rows: []string{}}
+	for x := 0; x < b.Height; x++ {
+		var row strings.Builder
+		for y := 0; y < b.Width; y++ {
+			if c := b.Cell(Coord{x, y}); c.IsColored() {
+				row.WriteString(c.Letter())
+			} else {
+				row.WriteString(emptyCellLetter)
+			}
+		}
+		jb.Rows = append(jb.Rows, row.String())
+	}
+	return json.Marshal(jb)
+}
+
+// UnmarshalJSON decodes the board from the format written by MarshalJSON.
+func (b *Board) UnmarshalJSON(data []byte) error {
+	var jb jsonBoard
+	if err := json.Unmarshal(data, &jb); err != nil {
+		return err
+	}
+	nb, err := NewRectBoard(jb.Height, jb.Width)
+	if err != nil {
+		return err
+	}
+	if len(jb.Rows) != jb.Height {
+		return fmt.Errorf("Board has %d rows, want %d", len(jb.Rows), jb.Height)
+	}
+	nb.initGrid()
+	for x, row := range jb.Rows {
+		if len(row) != jb.Width {
+			return fmt.Errorf("Board row %d has %d cells, want %d", x, len(row), jb.Width)
+		}
+		for y, letter := range row {
+			if string(letter) == emptyCellLetter {
+				continue
+			}
+			c, ok := colorFromLetter(string(letter))
+			if !ok {
+				return fmt.Errorf("Unknown color letter in board row %d: %q", x, letter)
+			}
+			nb.SetCell(Coord{x, y}, c)
+		}
+	}
+	*b = *nb
+	return nil
+}
+
+func toJSONPlayer(p *Player) *jsonPlayer {
+	jp := &jsonPlayer{
+		Name:      p.Name,
+		Color:     p.Color,
+		StartPos:  [2]int{p.StartPos.X, p.StartPos.Y},
+		NumPieces: len(p.PlacedPieces),
+		Placed:    []int{},
+		Status:    p.Status.String(),
+		SharedBy:  p.SharedBy,
+		Owner:     p.Owner,
+	}
+	for i, placed := range p.PlacedPieces {
+		if placed {
+			jp.Placed = append(jp.Placed, i)
+		}
+	}
+	return jp
+}
+
+func (jp *jsonPlayer) toPlayer() (*Player, error) {
+	p, err := NewPlayer(jp.Name, jp.Color, Coord{jp.StartPos[0], jp.StartPos[1]}, jp.NumPieces)
+	if err != nil {
+		return nil, err
+	}
+	for _, i := range jp.Placed {
+		if err := p.placePiece(i); err != nil {
+			return nil, fmt.Errorf("Invalid placed piece of player %v: %v", jp.Name, err)
+		}
+	}
+	if p.Status, err = parsePlayerStatus(jp.Status); err != nil {
+		return nil, err
+	}
+	p.SharedBy = jp.SharedBy
+	p.Owner = jp.Owner
+	return p, nil
+}
+
+// MarshalJSON encodes the player, with the placed pieces given by their indexes.
+func (p Player) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toJSONPlayer(&p))
+}
+
+// UnmarshalJSON decodes the player from the format written by MarshalJSON.
+func (p *Player) UnmarshalJSON(b []byte) error {
+	var jp jsonPlayer
+	if err := json.Unmarshal(b, &jp); err != nil {
+		return err
+	}
+	np, err := jp.toPlayer()
+	if err != nil {
+		return err
+	}
+	*p = *np
+	return nil
+}
+
+func toJSONMove(m *Move) *jsonMove {
+	jm := &jsonMove{
+		Piece:  m.PieceIndex,
+		Loc:    [2]int{m.Loc.X, m.Loc.Y},
+		Resign: m.Resign,
+	}
+	if m.placesPiece() {
+		jm.Orient = m.Orient.String()
+	}
+	return jm
+}
+
+func (jm *jsonMove) toMove(player *Player) (*Move, error) {
+	m := &Move{
+		Player:     player,
+		PieceIndex: jm.Piece,
+		Loc:        Coord{jm.Loc[0], jm.Loc[1]},
+		Resign:     jm.Resign,
+	}
+	if jm.Resign && jm.Piece >= 0 {
+		return nil, fmt.Errorf("Resignation cannot place piece %d", jm.Piece)
+	}
+	if len(jm.Orient) > 0 {
+		var err error
+		if m.Orient, err = ParseOrientation(jm.Orient); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// MarshalJSON encodes the move with the player given by its color.
+func (m Move) MarshalJSON() ([]byte, error) {
+	if m.Player == nil {
+		return nil, fmt.Errorf("Move has no player")
+	}
+	jm := toJSONMove(&m)
+	jm.Color = m.Player.Color
+	return json.Marshal(jm)
+}
+
+// UnmarshalJSON decodes the move from the format written by MarshalJSON.
+// The move's player only has the color set, so it should be matched to a player of the game by color,
+// as Replay does.
+func (m *Move) UnmarshalJSON(b []byte) error {
+	var jm jsonMove
+	if err := json.Unmarshal(b, &jm); err != nil {
+		return err
+	}
+	if !jm.Color.IsColored() {
+		return fmt.Errorf("Move has invalid color: %v", jm.Color)
+	}
+	nm, err := jm.toMove(&Player{Color: jm.Color})
+	if err != nil {
+		return err
+	}
+	*m = *nm
+	return nil
+}
+
+// MarshalJSON encodes the game with the version of the format. Moves refer to players by their index.
+func (g *Game) MarshalJSON() ([]byte, error) {
+	jg := jsonGame{
+		Version:             JSONVersion,
+		Variant:             g.Variant,
+		Board:               g.Board,
+		Pieces:              []*jsonPiece{},
+		Players:             []*jsonPlayer{},
+		CurPlayerIndex:      g.CurPlayerIndex,
+		FirstPlayerIndex:    g.FirstPlayerIndex,
+		ForbidVoluntaryPass: g.ForbidVoluntaryPass,
+		Moves:               []*jsonMove{},
+	}
+	for _, p := range g.Pieces {
+		jp := &jsonPiece{Name: p.Name}
+		for _, b := range p.Blocks {
+			jp.Blocks = append(jp.Blocks, [2]int{b.X, b.Y})
+		}
+		jg.Pieces = append(jg.Pieces, jp)
+	}
+	for _, p := range g.Players {
+		jg.Players = append(jg.Players, toJSONPlayer(p))
+	}
+	var err error
+	if jg.Moves, err = g.toJSONMoves(g.Moves); err != nil {
+		return nil, err
+	}
+	if jg.Redos, err = g.toJSONMoves(g.Redos); err != nil {
+		return nil, err
+	}
+	return json.Marshal(jg)
+}
+
+// toJSONMoves encodes the moves with their players' indexes. A move's player may be a copy of
+// the game's player, as in games loaded from datastore, so players are also matched by color.
+func (g *Game) toJSONMoves(moves []*Move) ([]*jsonMove, error) {
+	jms := []*jsonMove{}
+	for i, m := range moves {
+		pi := g.playerIndex(m.Player)
+		if pi < 0 && m.Player != nil {
+			pi = g.playerIndex(g.playerByColor(m.Player.Color))
+		}
+		if pi < 0 {
+			return nil, fmt.Errorf("Player of move %d is not in the game", i)
+		}
+		jm := toJSONMove(m)
+		jm.Player = &pi
+		jms = append(jms, jm)
+	}
+	return jms, nil
+}
+
+// UnmarshalJSON decodes the game from the format written by MarshalJSON.
+// The pieces and players are checked, but the moves are not replayed.
+func (g *Game) UnmarshalJSON(b []byte) error {
+	var jg jsonGame
+	if err := json.Unmarshal(b, &jg); err != nil {
+		return err
+	}
+	if jg.Version != JSONVersion {
+		return fmt.Errorf("Unsupported game format version %d, want %d", jg.Version, JSONVersion)
+	}
+	if jg.Board == nil {
+		return fmt.Errorf("Game has no board")
+	}
+	ng := &Game{
+		Board:               jg.Board,
+		CurPlayerIndex:      jg.CurPlayerIndex,
+		FirstPlayerIndex:    jg.FirstPlayerIndex,
+		Variant:             jg.Variant,
+		ForbidVoluntaryPass: jg.ForbidVoluntaryPass,
+	}
+	for i, jp := range jg.Pieces {
+		var blocks []Coord
+		for _, b := range jp.Blocks {
+			blocks = append(blocks, Coord{b[0], b[1]})
+		}
+		p, err := NewPiece(blocks)
+		if err != nil {
+			return fmt.Errorf("Invalid piece %d: %v", i, err)
+		}
+		p.Name = jp.Name
+		ng.Pieces = append(ng.Pieces, p)
+	}
+	if err := ValidatePieces(ng.Pieces); err != nil {
+		return err
+	}
+	for _, jp := range jg.Players {
+		p, err := jp.toPlayer()
+		if err != nil {
+			return err
+		}
+		if len(p.PlacedPieces) != len(ng.Pieces) {
+			return fmt.Errorf("Player %v has %d pieces, want %d", p.Name, len(p.PlacedPieces), len(ng.Pieces))
+		}
+		ng.Players = append(ng.Players, p)
+	}
+	if len(ng.Players) > 0 {
+		if ng.CurPlayerIndex < 0 || ng.CurPlayerIndex >= len(ng.Players) {
+			return fmt.Errorf("Current player index out of range: %d", ng.CurPlayerIndex)
+		}
+		if ng.FirstPlayerIndex < 0 || ng.FirstPlayerIndex >= len(ng.Players) {
+			return fmt.Errorf("First player index out of range: %d", ng.FirstPlayerIndex)
+		}
+	}
+	var err error
+	if ng.Moves, err = ng.fromJSONMoves(jg.Moves); err != nil {
+		return err
+	}
+	if ng.Redos, err = ng.fromJSONMoves(jg.Redos); err != nil {
+		return err
+	}
+	*g = *ng
+	return nil
+}
+
+func (g *Game) fromJSONMoves(jms []*jsonMove) ([]*Move, error) {
+	var moves []*Move
+	for i, jm := range jms {
+		if jm.Player == nil || *jm.Player < 0 || *jm.Player >= len(g.Players) {
+			return nil, fmt.Errorf("Move %d has invalid player index", i)
+		}
+		if jm.Piece >= len(g.Pieces) {
+			return nil, fmt.Errorf("Move %d has piece index out of range: %d", i, jm.Piece)
+		}
+		m, err := jm.toMove(g.Players[*jm.Player])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid move %d: %v", i, err)
+		}
+		moves = append(moves, m)
+	}
+	return moves, nil
+}
@@ -0,0 +1,222 @@
+package blokus
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGameJSONRoundTrip(t *testing.T) {
+	g, err := ThreePlayerVariant().NewGame(DefaultPieces(), []string{"alice", "bob", "carol"})
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	playRandomly(t, g, 30)
+	if _, err := g.Undo(); err != nil {
+		t.Fatalf("Undo(): got %v, want no error", err)
+	}
+	g.Players[1].Status = StatusResigned
+
+	b, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal(): got %v, want no error", err)
+	}
+	got := &Game{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatalf("Unmarshal(): got %v, want no error", err)
+	}
+
+	if !reflect.DeepEqual(got.Board.Grid, g.Board.Grid) {
+		t.Error("Unmarshaled board: got different grid, want same grid")
+	}
+	if !reflect.DeepEqual(got.Players, g.Players) {
+		t.Errorf("Unmarshaled players: got %v, want %v", got.Players, g.Players)
+	}
+	for i, p := range got.Pieces {
+		if p.Name != g.Pieces[i].Name || !reflect.DeepEqual(p.Blocks, g.Pieces[i].Blocks) {
+			t.Errorf("Unmarshaled piece %d: got %v %v, want %v %v", i, p.Name, p.Blocks, g.Pieces[i].Name, g.Pieces[i].Blocks)
+		}
+	}
+	if got, want := len(got.Moves), len(g.Moves); got != want {
+		t.Fatalf("Unmarshaled moves len: got %d, want %d", got, want)
+	}
+	for i, m := range got.Moves {
+		if got, want := got.playerIndex(m.Player), g.playerIndex(g.Moves[i].Player); got != want {
+			t.Errorf("Unmarshaled move %d player: got index %d, want %d", i, got, want)
+		}
+		if got, want := got.FormatMove(*m), g.FormatMove(*g.Moves[i]); got != want {
+			t.Errorf("Unmarshaled move %d: got %v, want %v", i, got, want)
+		}
+	}
+	if got, want := len(got.Redos), 1; got != want {
+		t.Errorf("Unmarshaled redos len: got %d, want %d", got, want)
+	}
+	if got.CurPlayerIndex != g.CurPlayerIndex || got.FirstPlayerIndex != g.FirstPlayerIndex || got.Variant != g.Variant {
+		t.Errorf("Unmarshaled game settings: got %v %v %v, want %v %v %v",
+			got.CurPlayerIndex, got.FirstPlayerIndex, got.Variant, g.CurPlayerIndex, g.FirstPlayerIndex, g.Variant)
+	}
+	if err := got.Verify(); err != nil {
+		t.Errorf("Verify() of unmarshaled game: got %v, want no error", err)
+	}
+
+	again, err := json.Marshal(got)
+	if err != nil {
+		t.Fatalf("Marshal() of unmarshaled game: got %v, want no error", err)
+	}
+	if string(again) != string(b) {
+		t.Errorf("Marshal() of unmarshaled game: got %s, want %s", again, b)
+	}
+}
+
+func TestGameJSONCopiedMovePlayers(t *testing.T) {
+	g, err := DuoVariant().NewGame(DefaultPieces(), []string{"alice", "bob"})
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	playRandomly(t, g, 4)
+	// Games loaded from datastore have copies of the players in their moves.
+	for _, m := range g.Moves {
+		m.Player = m.Player.clone()
+	}
+	b, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal(): got %v, want no error", err)
+	}
+	got := &Game{}
+	if err := json.Unmarshal(b, got); err != nil {
+		t.Fatalf("Unmarshal(): got %v, want no error", err)
+	}
+	for i, m := range got.Moves {
+		if got, want := m.Player.Color, g.Moves[i].Player.Color; got != want {
+			t.Errorf("Unmarshaled move %d color: got %v, want %v", i, got, want)
+		}
+	}
+	g.Moves[0].Player = &Player{Name: "nobody", Color: Blue}
+	if _, err := json.Marshal(g); err == nil {
+		t.Error("Marshal() with a move by a color not in the game: got no error, want error")
+	}
+}
+
+func TestBoardJSON(t *testing.T) {
+	b, err := NewRectBoard(2, 3)
+	if err != nil {
+		t.Fatalf("NewRectBoard(): got %v, want no error", err)
+	}
+	b.SetCell(Coord{0, 1}, Blue)
+	b.SetCell(Coord{1, 2}, Orange)
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("Marshal(): got %v, want no error", err)
+	}
+	if got, want := string(data), `{"height":2,"width":3,"rows":[".B.","..O"]}`; got != want {
+		t.Errorf("Marshal(): got %s, want %s", got, want)
+	}
+	got := &Board{}
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("Unmarshal(): got %v, want no error", err)
+	}
+	if !reflect.DeepEqual(got.Grid, b.Grid) {
+		t.Errorf("Unmarshal(): got grid %v, want %v", got.Grid, b.Grid)
+	}
+	if got, want := got.CornerAnchors(Blue), b.CornerAnchors(Blue); !reflect.DeepEqual(got, want) {
+		t.Errorf("CornerAnchors() of unmarshaled board: got %v, want %v", got, want)
+	}
+
+	for _, s := range []string{
+		`{"height":2,"width":3,"rows":[".B."]}`,
+		`{"height":2,"width":3,"rows":[".B.",".."]}`,
+		`{"height":1,"width":1,"rows":["Q"]}`,
+		`{"height":0,"width":1,"rows":[]}`,
+	} {
+		if err := json.Unmarshal([]byte(s), &Board{}); err == nil {
+			t.Errorf("Unmarshal(%s): got no error, want error", s)
+		}
+	}
+}
+
+func TestMoveJSON(t *testing.T) {
+	m := Move{Player: &Player{Name: "foo", Color: Yellow}, PieceIndex: 19, Orient: Orientation{Rot90, true}, Loc: Coord{4, 7}}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("Marshal(): got %v, want no error", err)
+	}
+	if got, want := string(data), `{"color":"yellow","piece":19,"orient":"r1f","loc":[4,7]}`; got != want {
+		t.Errorf("Marshal(): got %s, want %s", got, want)
+	}
+	var got Move
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal(): got %v, want no error", err)
+	}
+	if got.Player == nil || got.Player.Color != Yellow || got.PieceIndex != 19 || got.Orient != m.Orient || got.Loc != m.Loc {
+		t.Errorf("Unmarshal(): got %v, want %v", got, m)
+	}
+	if err := json.Unmarshal([]byte(`{"piece":1,"loc":[0,0]}`), &got); err == nil {
+		t.Error("Unmarshal() with no color: got no error, want error")
+	}
+}
+
+func TestPlayerJSON(t *testing.T) {
+	p, err := NewPlayer("foo", Green, Coord{19, 0}, 3)
+	if err != nil {
+		t.Fatalf("NewPlayer(): got %v, want no error", err)
+	}
+	p.PlacedPieces[1] = true
+	p.Status = StatusOutOfMoves
+	p.Owner = "bar"
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Marshal(): got %v, want no error", err)
+	}
+	want := `{"name":"foo","color":"green","startPos":[19,0],"numPieces":3,"placed":[1],"status":"out of moves","owner":"bar"}`
+	if got := string(data); got != want {
+		t.Errorf("Marshal(): got %s, want %s", got, want)
+	}
+	got := &Player{}
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("Unmarshal(): got %v, want no error", err)
+	}
+	if !reflect.DeepEqual(got, p) {
+		t.Errorf("Unmarshal(): got %v, want %v", got, p)
+	}
+}
+
+func TestColorJSON(t *testing.T) {
+	var cs []Color
+	if err := json.Unmarshal([]byte(`["red", 2, "Purple", 0]`), &cs); err != nil {
+		t.Fatalf("Unmarshal(): got %v, want no error", err)
+	}
+	if got, want := cs, []Color{Red, Yellow, Purple, colorEmpty}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Unmarshal(): got %v, want %v", got, want)
+	}
+	for _, s := range []string{`"pink"`, `9`, `true`} {
+		var c Color
+		if err := json.Unmarshal([]byte(s), &c); err == nil {
+			t.Errorf("Unmarshal(%s): got no error, want error", s)
+		}
+	}
+}
+
+func TestGameJSONErrors(t *testing.T) {
+	g := newMidGame(t, 4)
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal(): got %v, want no error", err)
+	}
+	for _, tc := range []struct{ old, new string }{
+		{`"version":1`, `"version":2`},
+		{`"currentPlayer":0`, `"currentPlayer":7`},
+		{`"player":0`, `"player":9`},
+		{`"numPieces":21`, `"numPieces":3`},
+		{`"blocks":[[0,0]]`, `"blocks":[[0,0],[0,0]]`},
+		{`"status":"active"`, `"status":"asleep"`},
+	} {
+		if !strings.Contains(string(data), tc.old) {
+			t.Fatalf("Marshal(): got %s, want it to contain %s", data, tc.old)
+		}
+		bad := strings.Replace(string(data), tc.old, tc.new, 1)
+		if err := json.Unmarshal([]byte(bad), &Game{}); err == nil {
+			t.Errorf("Unmarshal() with %s: got no error, want error", tc.new)
+		}
+	}
+}
@@ -23,6 +23,9 @@ const (
 	Yellow
 	Red
 	Green
+	// Purple and Orange are only played when chosen, as in Duo. They're never picked as a free color.
+	Purple
+	Orange
 
 	colorEnd
 )
@@ -44,10 +47,46 @@ func (c Color) String() string {
 		return "red"
 	case Green:
 		return "green"
+	case Purple:
+		return "purple"
+	case Orange:
+		return "orange"
 	}
 	return "unknown color"
 }
 
+// PlayerStatus is the state of a player during a game.
+type PlayerStatus uint8
+
+const (
+	// StatusActive means the player still takes turns. This is the zero value.
+	StatusActive PlayerStatus = iota
+	// StatusOutOfMoves means none of the player's remaining pieces fit on the board anymore.
+	StatusOutOfMoves
+	// StatusResigned means the player gave up.
+	StatusResigned
+	// StatusAllPlaced means the player placed every piece.
+	StatusAllPlaced
+)
+
+func (s PlayerStatus) IsActive() bool {
+	return s == StatusActive
+}
+
+func (s PlayerStatus) String() string {
+	switch s {
+	case StatusActive:
+		return "active"
+	case StatusOutOfMoves:
+		return "out of moves"
+	case StatusResigned:
+		return "resigned"
+	case StatusAllPlaced:
+		return "all pieces placed"
+	}
+	return "unknown status"
+}
+
 type Player struct {
 	// Name is the name of the player.
 	Name string
@@ -57,6 +96,27 @@ type Player struct {
 	StartPos Coord
 	// PlacedPieces stores whether a piece at the corresponding index has been placed on the board.
 	PlacedPieces []bool `datastore:",noindex"`
+	// Status is whether the player still takes turns, or why not.
+	Status PlayerStatus
+	// SharedBy are the names of who take turns playing this color in rotation.
+	// Empty unless this is a shared color, whose pieces don't count toward the standings.
+	SharedBy []string `datastore:",noindex"`
+	// Owner is the name of the person playing this color, if that person plays several colors.
+	// Empty if the person is the player named Name.
+	Owner string
+}
+
+// Participant returns the name of the person playing this color.
+func (p *Player) Participant() string {
+	if p.Owner != "" {
+		return p.Owner
+	}
+	return p.Name
+}
+
+// IsShared returns whether the player is a color shared by several people.
+func (p *Player) IsShared() bool {
+	return len(p.SharedBy) > 0
 }
 
 func NewPlayer(name string, color Color, startPos Coord, numPieces int) (*Player, error) {
@@ -96,12 +156,30 @@ func (p *Player) placePiece(index int) error {
 	return nil
 }
 
+// HasPlacedAllPieces returns whether every piece of the player is on the board.
+func (p *Player) HasPlacedAllPieces() bool {
+	for _, placed := range p.PlacedPieces {
+		if !placed {
+			return false
+		}
+	}
+	return true
+}
+
 // Board represents the game board.
+// Reads such as Hash and the move checks fill in cached state on first use, so a board isn't safe to read
+// from several goroutines at once. Use a Snapshot to share a position between goroutines.
 type Board struct {
 	// Height and Width of the board.
 	Height, Width int
 	// Grid stores the cells of the board in consecutive rows from top-left corner.
+	// Change cells through SetCell, which also keeps the cached bitboard in sync.
 	Grid []Color `datastore:",noindex,omitempty"`
+	// Bitboard of the grid, built when first needed.
+	bits *bitboard
+	// Zobrist hash of the grid, calculated when first needed if hashed is false.
+	hash   uint64
+	hashed bool
 }
 
 func NewBoard(size int) (*Board, error) {
@@ -140,7 +218,10 @@ func (b *Board) SetCell(coord Coord, color Color) {
 	if b.IsOutOfBounds(coord) {
 		return
 	}
-	b.Grid[coord.X*b.Width+coord.Y] = color
+	i := coord.X*b.Width + coord.Y
+	b.updateBitboard(coord, b.Grid[i], color)
+	b.updateHash(i, b.Grid[i], color)
+	b.Grid[i] = color
 }
 
 func (b *Board) Row(r int) []Color {
@@ -157,13 +238,39 @@ func (b *Board) IsOutOfBounds(c Coord) bool {
 	return c.X < 0 || c.Y < 0 || c.X >= b.Height || c.Y >= b.Width
 }
 
+// TransformCoord maps a cell to where it would be if the whole board was turned or flipped by the orientation
+// around its center. Turning a board that isn't square by 90 or 270 degrees maps cells out of bounds.
+func (b *Board) TransformCoord(o Orientation, c Coord) Coord {
+	// Double the coordinates relative to the center, so that it's on integer coordinates.
+	d := o.Transform(Coord{2*c.X - (b.Height - 1), 2*c.Y - (b.Width - 1)})
+	return Coord{(d.X + b.Height - 1) / 2, (d.Y + b.Width - 1) / 2}
+}
+
+// CornerAnchors returns the empty cells that diagonally touch a cell of the given color without sharing an edge with one.
+// These are the only cells where a new piece of that color can connect to the existing ones.
+// Cells are returned in row-major order.
+func (b *Board) CornerAnchors(color Color) []Coord {
+	var anchors []Coord
+	if len(b.Grid) == 0 || !color.IsColored() {
+		return anchors
+	}
+	b.bitboard().anchors[color].forEach(func(i int) {
+		anchors = append(anchors, Coord{i / b.Width, i % b.Width})
+	})
+	return anchors
+}
+
 // Piece represents a puzzle piece, made up of one or more square blocks.
 type Piece struct {
+	// Name is the conventional name of the piece, e.g. "F5". It's empty for pieces outside the standard set.
+	Name string
 	// Blocks is the square blocks this piece consists of. First block must be at (0,0) with other blocks relative to it.
 	// The blocks are stored in their original coordinates with no rotation or flipping. Orientation is used to calcuate the actual coordinates.
 	Blocks []Coord `datastore:",noindex"`
 	// The corner squares of this piece, which was calculated from blocks and cached here.
 	corners []Coord
+	// The blocks of this piece in every orientation, which was calculated from blocks and cached here.
+	shapes []pieceShape
 }
 
 func NewPiece(blocks []Coord) (*Piece, error) {
@@ -174,6 +281,7 @@ func NewPiece(blocks []Coord) (*Piece, error) {
 		// Make a copy, in case the same block slice is used to make other pieces.
 		Blocks: append([]Coord(nil), blocks...),
 	}
+	p.warmCaches()
 	return p, nil
 }
 
@@ -192,6 +300,12 @@ func (p *Piece) Corners() []Coord {
 	return p.corners
 }
 
+// warmCaches calculates the cached fields up front, so that the piece can be read concurrently afterwards.
+func (p *Piece) warmCaches() {
+	p.Corners()
+	p.orientedShapes()
+}
+
 func getCorners(blocks []Coord) []Coord {
 	corners := map[Coord]bool{}
 	// Add corners of all blocks
@@ -219,15 +333,23 @@ func getCorners(blocks []Coord) []Coord {
 type Move struct {
 	// Player is the player who made the move. Cannot be nil.
 	Player *Player
-	// PieceIndex is the index of the piece that was played. Negative if the turn was passed.
+	// PieceIndex is the index of the piece that was played. Negative if the turn was passed or the player resigned.
 	PieceIndex int
 	// Orient is the orientation of the piece when played.
 	Orient Orientation
 	// Loc is the location on the board where the piece was played.
 	// This is the coordinate where the (0,0) block of the piece is located.
 	Loc Coord
+	// Resign is whether the player resigned instead, which may be out of turn. The piece index is negative then.
+	Resign bool
 }
 
+// IsPass returns whether the move passes the turn without placing a piece.
 func (m Move) IsPass() bool {
-	return m.PieceIndex < 0
+	return m.PieceIndex < 0 && !m.Resign
+}
+
+// placesPiece returns whether the move puts a piece on the board.
+func (m Move) placesPiece() bool {
+	return m.PieceIndex >= 0 && !m.Resign
 }
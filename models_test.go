@@ -13,6 +13,8 @@ func TestColorIsValid(t *testing.T) {
 		Yellow,
 		Red,
 		Green,
+		Purple,
+		Orange,
 	}
 	for _, c := range colors {
 		t.Run(fmt.Sprintf("Color(%v)", c), func(t *testing.T) {
@@ -46,6 +48,8 @@ func TestColorString(t *testing.T) {
 		{Yellow, "yellow"},
 		{Red, "red"},
 		{Green, "green"},
+		{Purple, "purple"},
+		{Orange, "orange"},
 	}
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("Color(%v)", tc.c), func(t *testing.T) {
@@ -279,3 +283,44 @@ func TestGetCorners_ThreeBlockL(t *testing.T) {
 		t.Errorf("getCorners(): got %v, want %v", got, want)
 	}
 }
+
+func TestBoardCornerAnchors(t *testing.T) {
+	b, err := NewBoard(5)
+	if err != nil {
+		t.Fatalf("NewBoard(): got error %v, want no error", err)
+	}
+	if got := b.CornerAnchors(Blue); len(got) != 0 {
+		t.Errorf("CornerAnchors() on empty board: got %v, want none", got)
+	}
+	b.SetCell(Coord{0, 0}, Blue)
+	b.SetCell(Coord{1, 0}, Blue)
+	b.SetCell(Coord{2, 2}, Yellow)
+	if got, want := b.CornerAnchors(Blue), []Coord{{2, 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CornerAnchors(blue): got %v, want %v", got, want)
+	}
+	if got, want := b.CornerAnchors(Yellow), []Coord{{1, 1}, {1, 3}, {3, 1}, {3, 3}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CornerAnchors(yellow): got %v, want %v", got, want)
+	}
+}
+
+func TestBoardTransformCoord(t *testing.T) {
+	b, err := NewBoard(20)
+	if err != nil {
+		t.Fatalf("NewBoard(): got error %v, want no error", err)
+	}
+	testCases := []struct {
+		o    Orientation
+		c    Coord
+		want Coord
+	}{
+		{Orientation{Rot0, false}, Coord{3, 4}, Coord{3, 4}},
+		{Orientation{Rot90, false}, Coord{0, 0}, Coord{0, 19}},
+		{Orientation{Rot180, false}, Coord{0, 0}, Coord{19, 19}},
+		{Orientation{Rot0, true}, Coord{2, 5}, Coord{2, 14}},
+	}
+	for _, tc := range testCases {
+		if got := b.TransformCoord(tc.o, tc.c); got != tc.want {
+			t.Errorf("TransformCoord(%v, %v): got %v, want %v", tc.o, tc.c, got, tc.want)
+		}
+	}
+}
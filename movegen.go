@@ -0,0 +1,109 @@
+package blokus
+
+// LegalMoves returns every valid placement of the player's remaining pieces on the current board.
+// Placements follow the same rules as PlacePiece, but whose turn it is is not checked.
+// Passing is not included in the returned moves.
+func (g *Game) LegalMoves(player *Player) []Move {
+	var moves []Move
+	g.forEachLegalMove(player, func(m Move) bool {
+		moves = append(moves, m)
+		return true
+	})
+	return moves
+}
+
+// HasLegalMove returns whether the player can place any of the remaining pieces.
+// It stops at the first valid placement, so it's cheaper than LegalMoves.
+func (g *Game) HasLegalMove(player *Player) bool {
+	found := false
+	g.forEachLegalMove(player, func(Move) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// pieceShape is the blocks of a piece in one orientation.
+type pieceShape struct {
+	orient Orientation
+	blocks []Coord
+}
+
+// orientedShapes returns the blocks of the piece in each of its unique orientations.
+func (p *Piece) orientedShapes() []pieceShape {
+	if p.shapes == nil {
+		var normalized [][]Coord
+	orients:
+		for _, o := range AllOrientations() {
+			blocks := o.TransformCoords(p.Blocks)
+			n := normalizeCoords(blocks)
+			for _, other := range normalized {
+				if equalCoords(n, other) {
+					continue orients
+				}
+			}
+			normalized = append(normalized, n)
+			p.shapes = append(p.shapes, pieceShape{orient: o, blocks: blocks})
+		}
+	}
+	return p.shapes
+}
+
+// forEachLegalMove calls fn for every legal placement of the player's pieces, until fn returns false.
+//
+// A piece must cover an anchor, which is a corner anchor of the player's color or the player's starting position,
+// and none of its blocks may be on a forbidden cell of the color. Every placement is generated once,
+// from the first of its blocks that covers an anchor.
+func (g *Game) forEachLegalMove(player *Player, fn func(Move) bool) {
+	if player == nil || g.Board == nil || !player.Color.IsColored() {
+		return
+	}
+	bb := g.Board.bitboard()
+	forbidden := bb.forbidden[player.Color]
+	isAnchor := append(bitset(nil), bb.anchors[player.Color]...)
+	if bb.inBounds(player.StartPos) && !forbidden.has(bb.index(player.StartPos)) {
+		isAnchor.set(bb.index(player.StartPos))
+	}
+	var anchors []Coord
+	isAnchor.forEach(func(i int) {
+		anchors = append(anchors, Coord{i / bb.width, i % bb.width})
+	})
+	if len(anchors) == 0 {
+		return
+	}
+
+	for i, piece := range g.Pieces {
+		if piece == nil || player.CheckPiecePlaceability(i) != nil {
+			continue
+		}
+		for _, shape := range piece.orientedShapes() {
+			for _, a := range anchors {
+				for bi, b := range shape.blocks {
+					loc := Coord{a.X - b.X, a.Y - b.Y}
+					if !fitsShape(bb, forbidden, isAnchor, shape.blocks, bi, loc) {
+						continue
+					}
+					if !fn(Move{Player: player, PieceIndex: i, Orient: shape.orient, Loc: loc}) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// fitsShape returns whether the blocks at the location are all on the board and not forbidden,
+// and none before the block at index anchorBlock covers an anchor.
+func fitsShape(bb *bitboard, forbidden, isAnchor bitset, blocks []Coord, anchorBlock int, loc Coord) bool {
+	for bj, c := range blocks {
+		c = Coord{loc.X + c.X, loc.Y + c.Y}
+		if !bb.inBounds(c) {
+			return false
+		}
+		i := bb.index(c)
+		if forbidden.has(i) || (bj < anchorBlock && isAnchor.has(i)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,227 @@
+package blokus
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// bruteForceLegalMoves checks every piece, orientation and location on the board.
+func bruteForceLegalMoves(g *Game, player *Player) map[Move]bool {
+	moves := map[Move]bool{}
+	for i, piece := range g.Pieces {
+		if player.PlacedPieces[i] {
+			continue
+		}
+		for _, o := range piece.UniqueOrientations() {
+			oriented := &Piece{Blocks: o.TransformCoords(piece.Blocks)}
+			for x := -5; x < g.Board.Height+5; x++ {
+				for y := -5; y < g.Board.Width+5; y++ {
+					if g.checkPiecePlacement(player, oriented, Coord{x, y}) == nil {
+						moves[Move{Player: player, PieceIndex: i, Orient: o, Loc: Coord{x, y}}] = true
+					}
+				}
+			}
+		}
+	}
+	return moves
+}
+
+func checkLegalMovesMatchBruteForce(t *testing.T, g *Game, player *Player) {
+	want := bruteForceLegalMoves(g, player)
+	got := map[Move]bool{}
+	for _, m := range g.LegalMoves(player) {
+		if got[m] {
+			t.Errorf("LegalMoves(%v): got duplicate move %v", player.Name, m)
+		}
+		got[m] = true
+		if !want[m] {
+			t.Errorf("LegalMoves(%v): got illegal move %v", player.Name, m)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("LegalMoves(%v) count: got %v, want %v", player.Name, len(got), len(want))
+	}
+	if got, want := g.HasLegalMove(player), len(want) > 0; got != want {
+		t.Errorf("HasLegalMove(%v): got %v, want %v", player.Name, got, want)
+	}
+}
+
+func TestLegalMovesFirstMove(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddPlayer("foo", Blue, Coord{0, 0}); err != nil {
+		t.Fatalf("AddPlayer(foo): got %v, want no error", err)
+	}
+	moves := g.LegalMoves(g.Players[0])
+	// The monomino can only go on the starting position, and all its orientations are the same.
+	if got, want := len(moves), 1; got != want {
+		t.Fatalf("LegalMoves() count: got %v, want %v", got, want)
+	}
+	for _, m := range moves {
+		if got, want := m.Loc, (Coord{0, 0}); got != want {
+			t.Errorf("LegalMoves() move location: got %v, want %v", got, want)
+		}
+		if m.Player != g.Players[0] {
+			t.Errorf("LegalMoves() move player: got %v, want %v", m.Player.Name, g.Players[0].Name)
+		}
+	}
+}
+
+func TestLegalMovesMatchBruteForce(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 6)
+	checkLegalMovesMatchBruteForce(t, g, g.Players[0])
+	checkLegalMovesMatchBruteForce(t, g, g.Players[1])
+
+	if err := g.PlacePiece(g.Players[0], 0, Orientation{Rot0, false}, Coord{0, 0}); err != nil {
+		t.Fatalf("PlacePiece(blue 1st piece): got %v, want no error", err)
+	}
+	checkLegalMovesMatchBruteForce(t, g, g.Players[0])
+	checkLegalMovesMatchBruteForce(t, g, g.Players[1])
+}
+
+func TestLegalMovesDefaultPieces(t *testing.T) {
+	g, err := NewGame(DefaultBoardSize, DefaultPieces())
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	if err := g.AddPlayer("foo", Blue, Coord{0, 0}); err != nil {
+		t.Fatalf("AddPlayer(foo): got %v, want no error", err)
+	}
+	if err := g.AddPlayer("bar", Yellow, Coord{0, DefaultBoardSize - 1}); err != nil {
+		t.Fatalf("AddPlayer(bar): got %v, want no error", err)
+	}
+	if err := g.PlacePiece(g.Players[0], 20, Orientation{Rot0, false}, Coord{1, 1}); err == nil {
+		t.Fatal("PlacePiece(X piece off start): got no error, want error")
+	}
+	if err := g.PlacePiece(g.Players[0], 19, Orientation{Rot0, false}, Coord{0, 0}); err != nil {
+		t.Fatalf("PlacePiece(blue F piece): got %v, want no error", err)
+	}
+	checkLegalMovesMatchBruteForce(t, g, g.Players[0])
+	checkLegalMovesMatchBruteForce(t, g, g.Players[1])
+}
+
+func TestHasLegalMoveAllPiecesPlaced(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddPlayer("foo", Blue, Coord{0, 0}); err != nil {
+		t.Fatalf("AddPlayer(foo): got %v, want no error", err)
+	}
+	if err := g.PlacePiece(g.Players[0], 0, Orientation{Rot0, false}, Coord{0, 0}); err != nil {
+		t.Fatalf("PlacePiece(): got %v, want no error", err)
+	}
+	if got := g.HasLegalMove(g.Players[0]); got {
+		t.Errorf("HasLegalMove() with all pieces placed: got %v, want false", got)
+	}
+	if got := g.LegalMoves(g.Players[0]); len(got) != 0 {
+		t.Errorf("LegalMoves() with all pieces placed: got %v, want none", got)
+	}
+}
+
+// referenceLegalMoves generates moves by scanning the grid for anchors and checking every placement with
+// checkPiecePlacement, as before the bitboard was added. It's kept to benchmark against.
+func referenceLegalMoves(g *Game, player *Player) []Move {
+	var anchors []Coord
+	for x := 0; x < g.Board.Height; x++ {
+		for y := 0; y < g.Board.Width; y++ {
+			c := Coord{x, y}
+			if c == player.StartPos && !g.Board.Cell(c).IsColored() {
+				anchors = append(anchors, c)
+				continue
+			}
+			if g.Board.Cell(c).IsColored() {
+				continue
+			}
+			edge, corner := false, false
+			for _, n := range neighbors {
+				edge = edge || g.Board.Cell(Coord{x + n.X, y + n.Y}) == player.Color
+			}
+			for _, d := range diagonals {
+				corner = corner || g.Board.Cell(Coord{x + d.X, y + d.Y}) == player.Color
+			}
+			if corner && !edge {
+				anchors = append(anchors, c)
+			}
+		}
+	}
+	var moves []Move
+	for i, piece := range g.Pieces {
+		if player.PlacedPieces[i] {
+			continue
+		}
+		for _, o := range piece.UniqueOrientations() {
+			oriented := &Piece{Blocks: o.TransformCoords(piece.Blocks)}
+			seen := map[Coord]bool{}
+			for _, a := range anchors {
+				for _, b := range oriented.Blocks {
+					loc := Coord{a.X - b.X, a.Y - b.Y}
+					if seen[loc] {
+						continue
+					}
+					seen[loc] = true
+					if g.checkPiecePlacement(player, oriented, loc) == nil {
+						moves = append(moves, Move{Player: player, PieceIndex: i, Orient: o, Loc: loc})
+					}
+				}
+			}
+		}
+	}
+	return moves
+}
+
+// newMidGame returns a classic game after some random moves.
+func newMidGame(tb testing.TB, turns int) *Game {
+	names := []string{"foo", "bar", "baz", "qux"}
+	g, err := ClassicVariant().NewGame(DefaultPieces(), names)
+	if err != nil {
+		tb.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < turns && !g.IsGameEnd(); i++ {
+		moves := g.LegalMoves(g.CurrentPlayer())
+		m := Move{Player: g.CurrentPlayer(), PieceIndex: -1}
+		if len(moves) > 0 {
+			m = moves[r.Intn(len(moves))]
+		}
+		if _, err := g.Play(m); err != nil {
+			tb.Fatalf("Play(%v): got %v, want no error", m, err)
+		}
+	}
+	return g
+}
+
+func TestLegalMovesMatchReference(t *testing.T) {
+	g := newMidGame(t, 40)
+	for _, p := range g.Players {
+		want := map[Move]bool{}
+		for _, m := range referenceLegalMoves(g, p) {
+			want[m] = true
+		}
+		got := g.LegalMoves(p)
+		if len(got) != len(want) {
+			t.Errorf("LegalMoves(%v) count: got %v, want %v", p.Name, len(got), len(want))
+		}
+		for _, m := range got {
+			if !want[m] {
+				t.Errorf("LegalMoves(%v): got illegal move %v", p.Name, m)
+			}
+		}
+	}
+}
+
+func BenchmarkLegalMoves(b *testing.B) {
+	g := newMidGame(b, 24)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range g.Players {
+			g.LegalMoves(p)
+		}
+	}
+}
+
+func BenchmarkLegalMovesReference(b *testing.B) {
+	g := newMidGame(b, 24)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, p := range g.Players {
+			referenceLegalMoves(g, p)
+		}
+	}
+}
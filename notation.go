@@ -0,0 +1,176 @@
+package blokus
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Move notation is a line of text like "B F5 r1f @ 4,7", in order:
+//   - the letter of the player's color, e.g. "B" for blue,
+//   - the name of the piece, or its index for pieces with no name,
+//   - the orientation, which is "r" followed by the number of clockwise rotations, and "f" if flipped,
+//   - "@" followed by the row and column of the piece's (0,0) block.
+// A pass is the color letter followed by "pass", e.g. "Y pass", and a resignation is the letter followed by "resign".
+
+var colorLetters = map[Color]string{
+	Blue:   "B",
+	Yellow: "Y",
+	Red:    "R",
+	Green:  "G",
+	Purple: "P",
+	Orange: "O",
+}
+
+// Letter returns the single letter that stands for the color in move notation.
+func (c Color) Letter() string {
+	if l, ok := colorLetters[c]; ok {
+		return l
+	}
+	return "?"
+}
+
+func colorFromLetter(s string) (Color, bool) {
+	for c, l := range colorLetters {
+		if strings.EqualFold(l, s) {
+			return c, true
+		}
+	}
+	return colorEmpty, false
+}
+
+// String formats the orientation in move notation, e.g. "r1f".
+func (o Orientation) String() string {
+	s := fmt.Sprintf("r%d", Normalize(o.Rot))
+	if o.Flip {
+		s += "f"
+	}
+	return s
+}
+
+// String formats the move in move notation, with the piece given by its index.
+// Use Game.FormatMove to name the piece.
+func (m Move) String() string {
+	return formatMove(m, strconv.Itoa(m.PieceIndex))
+}
+
+// FormatMove formats the move in move notation, e.g. "B F5 r1f @ 4,7".
+func (g *Game) FormatMove(m Move) string {
+	return formatMove(m, g.PieceName(m.PieceIndex))
+}
+
+func formatMove(m Move, piece string) string {
+	var parts []string
+	if m.Player != nil {
+		parts = append(parts, m.Player.Color.Letter())
+	}
+	switch {
+	case m.Resign:
+		parts = append(parts, "resign")
+	case m.IsPass():
+		parts = append(parts, "pass")
+	default:
+		parts = append(parts, piece, m.Orient.String(), fmt.Sprintf("@ %d,%d", m.Loc.X, m.Loc.Y))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseMove parses a move in move notation. Letters are case insensitive.
+// The color may be left out to make the move for the current player, and the orientation may be left out
+// for an unrotated and unflipped piece, e.g. "f5 @ 4,7". The move is not checked against the board.
+func (g *Game) ParseMove(s string) (Move, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return Move{}, fmt.Errorf("Move is empty")
+	}
+
+	var m Move
+	if c, ok := colorFromLetter(fields[0]); ok {
+		if m.Player = g.playerByColor(c); m.Player == nil {
+			return Move{}, fmt.Errorf("No player with color %v", c)
+		}
+		fields = fields[1:]
+	} else {
+		if len(g.Players) == 0 {
+			return Move{}, fmt.Errorf("No players in the game")
+		}
+		m.Player = g.CurrentPlayer()
+	}
+	if len(fields) == 0 {
+		return Move{}, fmt.Errorf("Move has no piece: %q", s)
+	}
+
+	if strings.EqualFold(fields[0], "pass") || strings.EqualFold(fields[0], "resign") {
+		if len(fields) > 1 {
+			return Move{}, fmt.Errorf("Unexpected text after %v: %q", fields[0], strings.Join(fields[1:], " "))
+		}
+		m.PieceIndex = -1
+		m.Resign = strings.EqualFold(fields[0], "resign")
+		return m, nil
+	}
+	var err error
+	if m.PieceIndex, err = g.parsePiece(fields[0]); err != nil {
+		return Move{}, err
+	}
+	fields = fields[1:]
+
+	if len(fields) > 0 && len(fields[0]) > 0 && (fields[0][0] == 'r' || fields[0][0] == 'R') {
+		if m.Orient, err = ParseOrientation(fields[0]); err != nil {
+			return Move{}, err
+		}
+		fields = fields[1:]
+	}
+
+	loc := strings.TrimPrefix(strings.Join(fields, ""), "@")
+	if m.Loc, err = parseCoord(loc); err != nil {
+		return Move{}, err
+	}
+	return m, nil
+}
+
+func (g *Game) parsePiece(s string) (int, error) {
+	if i, err := g.PieceIndex(s); err == nil {
+		return i, nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return -1, fmt.Errorf("Unknown piece: %v", s)
+	}
+	if i < 0 || i >= len(g.Pieces) {
+		return -1, fmt.Errorf("Piece index out of range: %d", i)
+	}
+	return i, nil
+}
+
+// ParseOrientation parses an orientation in move notation, e.g. "r1f".
+func ParseOrientation(s string) (Orientation, error) {
+	s = strings.ToLower(s)
+	if !strings.HasPrefix(s, "r") {
+		return Orientation{}, fmt.Errorf("Orientation must start with 'r': %q", s)
+	}
+	var o Orientation
+	digits := strings.TrimSuffix(s[1:], "f")
+	o.Flip = len(digits) < len(s)-1
+	r, err := strconv.Atoi(digits)
+	if err != nil || r < 0 || r >= int(rotEnd) {
+		return Orientation{}, fmt.Errorf("Invalid rotation in orientation: %q", s)
+	}
+	o.Rot = Rotation(r)
+	return o, nil
+}
+
+func parseCoord(s string) (Coord, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return Coord{}, fmt.Errorf("Location must be 'row,column': %q", s)
+	}
+	x, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Coord{}, fmt.Errorf("Invalid row in location: %q", s)
+	}
+	y, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Coord{}, fmt.Errorf("Invalid column in location: %q", s)
+	}
+	return Coord{x, y}, nil
+}
@@ -0,0 +1,91 @@
+package blokus
+
+import (
+	"testing"
+)
+
+func TestFormatMove(t *testing.T) {
+	g := newMidGame(t, 0)
+	blue := g.Players[0]
+	testCases := []struct {
+		m    Move
+		want string
+	}{
+		{Move{Player: blue, PieceIndex: 19, Orient: Orientation{Rot90, true}, Loc: Coord{4, 7}}, "B F5 r1f @ 4,7"},
+		{Move{Player: blue, PieceIndex: 0, Orient: Orientation{Rot180 + 4, false}, Loc: Coord{0, -1}}, "B I1 r2 @ 0,-1"},
+		{Move{Player: g.Players[1], PieceIndex: -1}, "Y pass"},
+	}
+	for _, tc := range testCases {
+		if got := g.FormatMove(tc.m); got != tc.want {
+			t.Errorf("FormatMove(%#v): got %q, want %q", tc.m, got, tc.want)
+		}
+	}
+	m := Move{Player: blue, PieceIndex: 19, Orient: Orientation{Rot90, true}, Loc: Coord{4, 7}}
+	if got, want := m.String(), "B 19 r1f @ 4,7"; got != want {
+		t.Errorf("String(): got %q, want %q", got, want)
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	g := newMidGame(t, 0)
+	blue, yellow := g.Players[0], g.Players[1]
+	testCases := []struct {
+		s    string
+		want Move
+	}{
+		{"B F5 r1f @ 4,7", Move{Player: blue, PieceIndex: 19, Orient: Orientation{Rot90, true}, Loc: Coord{4, 7}}},
+		{"y x5 R3 @ -1,2", Move{Player: yellow, PieceIndex: 20, Orient: Orientation{Rot270, false}, Loc: Coord{-1, 2}}},
+		{"Y 3 r0f @4,5", Move{Player: yellow, PieceIndex: 3, Orient: Orientation{Rot0, true}, Loc: Coord{4, 5}}},
+		{"i2 @ 1,1", Move{Player: blue, PieceIndex: 1, Loc: Coord{1, 1}}},
+		{"  Y   PASS ", Move{Player: yellow, PieceIndex: -1}},
+		{"pass", Move{Player: blue, PieceIndex: -1}},
+	}
+	for _, tc := range testCases {
+		got, err := g.ParseMove(tc.s)
+		if err != nil {
+			t.Errorf("ParseMove(%q): got error %v, want no error", tc.s, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseMove(%q): got %v, want %v", tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestParseMoveErrors(t *testing.T) {
+	g := newMidGame(t, 0)
+	for _, s := range []string{
+		"",
+		"B",
+		"P F5 @ 1,1",
+		"B Q7 @ 1,1",
+		"B 21 @ 1,1",
+		"B F5 r4 @ 1,1",
+		"B F5 rx @ 1,1",
+		"B F5 r1",
+		"B F5 r1 @ 1",
+		"B F5 r1 @ a,1",
+		"B pass now",
+	} {
+		if m, err := g.ParseMove(s); err == nil {
+			t.Errorf("ParseMove(%q): got %v, want error", s, m)
+		}
+	}
+}
+
+func TestParseFormattedMoves(t *testing.T) {
+	g := newMidGame(t, 20)
+	for _, p := range g.Players {
+		for _, m := range append(g.LegalMoves(p), Move{Player: p, PieceIndex: -1}) {
+			for _, s := range []string{g.FormatMove(m), m.String()} {
+				got, err := g.ParseMove(s)
+				if err != nil {
+					t.Fatalf("ParseMove(%q): got error %v, want no error", s, err)
+				}
+				if got != m {
+					t.Fatalf("ParseMove(%q): got %v, want %v", s, got, m)
+				}
+			}
+		}
+	}
+}
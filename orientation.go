@@ -1,5 +1,9 @@
 package blokus
 
+import (
+	"fmt"
+)
+
 type Rotation uint8
 
 const (
@@ -15,21 +19,52 @@ func Normalize(r Rotation) Rotation {
 	return r % rotEnd
 }
 
+// Orientation is one of the 8 symmetries of a square, i.e. the dihedral group D4.
+// A coordinate is first rotated clockwise in 90-degree steps, then flipped horizontally if Flip is true.
 type Orientation struct {
 	Rot  Rotation
 	Flip bool
 }
 
-func (o Orientation) TransformCoords(cs []Coord) []Coord {
-	out := make([]Coord, 0, len(cs))
-	for _, c := range cs {
-		out = append(out, o.Transform(c))
+// Matrix is a 2x2 integer matrix that transforms a coordinate (X,Y) as a column vector.
+type Matrix [2][2]int
+
+func (m Matrix) mul(n Matrix) Matrix {
+	var out Matrix
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 2; j++ {
+			out[i][j] = m[i][0]*n[0][j] + m[i][1]*n[1][j]
+		}
 	}
 	return out
 }
 
-func (o Orientation) Transform(c Coord) Coord {
-	// TODO: Use rotation matrix instead of looping
+func (m Matrix) transpose() Matrix {
+	return Matrix{{m[0][0], m[1][0]}, {m[0][1], m[1][1]}}
+}
+
+func (m Matrix) apply(c Coord) Coord {
+	return Coord{m[0][0]*c.X + m[0][1]*c.Y, m[1][0]*c.X + m[1][1]*c.Y}
+}
+
+var (
+	// Matrices of all orientations, indexed by flip then rotation.
+	orientMatrices [2][rotEnd]Matrix
+)
+
+func init() {
+	for f := 0; f < 2; f++ {
+		for r := Rot0; r < rotEnd; r++ {
+			// Columns are where the unit vectors end up.
+			x := transformSlow(Orientation{r, f == 1}, Coord{1, 0})
+			y := transformSlow(Orientation{r, f == 1}, Coord{0, 1})
+			orientMatrices[f][r] = Matrix{{x.X, y.X}, {x.Y, y.Y}}
+		}
+	}
+}
+
+// transformSlow applies the orientation one step at a time.
+func transformSlow(o Orientation, c Coord) Coord {
 	for i := 0; i < int(Normalize(o.Rot)); i++ {
 		c = rotateCoord(c)
 	}
@@ -39,6 +74,79 @@ func (o Orientation) Transform(c Coord) Coord {
 	return c
 }
 
+// Matrix returns the matrix that transforms coordinates like the orientation.
+func (o Orientation) Matrix() Matrix {
+	f := 0
+	if o.Flip {
+		f = 1
+	}
+	return orientMatrices[f][Normalize(o.Rot)]
+}
+
+// OrientationFromMatrix returns the orientation that transforms coordinates like the matrix.
+// It's an error if the matrix is not one of the 8 symmetries of a square.
+func OrientationFromMatrix(m Matrix) (Orientation, error) {
+	for _, o := range AllOrientations() {
+		if o.Matrix() == m {
+			return o, nil
+		}
+	}
+	return Orientation{}, fmt.Errorf("Matrix is not an orientation: %v", m)
+}
+
+func orientationFromMatrixOrDie(m Matrix) Orientation {
+	o, err := OrientationFromMatrix(m)
+	if err != nil {
+		// Products and inverses of orientation matrices are always orientation matrices.
+		panic(err)
+	}
+	return o
+}
+
+// Compose returns the orientation equivalent to applying this orientation, then the next one.
+func (o Orientation) Compose(next Orientation) Orientation {
+	return orientationFromMatrixOrDie(next.Matrix().mul(o.Matrix()))
+}
+
+// Inverse returns the orientation that undoes this orientation.
+func (o Orientation) Inverse() Orientation {
+	// Orientation matrices are orthogonal, so the inverse is the transpose.
+	return orientationFromMatrixOrDie(o.Matrix().transpose())
+}
+
+// Equal returns whether both orientations transform coordinates the same way.
+func (o Orientation) Equal(other Orientation) bool {
+	return o.Matrix() == other.Matrix()
+}
+
+// RotateClockwise returns the orientation after turning this one 90 degrees clockwise.
+func (o Orientation) RotateClockwise() Orientation {
+	return o.Compose(Orientation{Rot: Rot90})
+}
+
+// RotateCounterClockwise returns the orientation after turning this one 90 degrees counter-clockwise.
+func (o Orientation) RotateCounterClockwise() Orientation {
+	return o.Compose(Orientation{Rot: Rot270})
+}
+
+// FlipHorizontally returns the orientation after mirroring this one horizontally.
+func (o Orientation) FlipHorizontally() Orientation {
+	return o.Compose(Orientation{Flip: true})
+}
+
+func (o Orientation) TransformCoords(cs []Coord) []Coord {
+	out := make([]Coord, 0, len(cs))
+	m := o.Matrix()
+	for _, c := range cs {
+		out = append(out, m.apply(c))
+	}
+	return out
+}
+
+func (o Orientation) Transform(c Coord) Coord {
+	return o.Matrix().apply(c)
+}
+
 func rotateCoord(c Coord) Coord {
 	return Coord{c.Y, -c.X}
 }
@@ -46,3 +154,14 @@ func rotateCoord(c Coord) Coord {
 func flipCoord(c Coord) Coord {
 	return Coord{c.X, -c.Y}
 }
+
+// AllOrientations returns all 8 combinations of rotation and flipping.
+func AllOrientations() []Orientation {
+	os := make([]Orientation, 0, 2*int(rotEnd))
+	for _, f := range []bool{false, true} {
+		for r := Rot0; r < rotEnd; r++ {
+			os = append(os, Orientation{Rot: r, Flip: f})
+		}
+	}
+	return os
+}
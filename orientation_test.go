@@ -94,3 +94,99 @@ func TestTransformCoords(t *testing.T) {
 		t.Errorf("TransformCoords(%v): got %v, want %v", o, got, want)
 	}
 }
+
+func TestTransformMatchesStepwise(t *testing.T) {
+	cs := []Coord{{0, 0}, {1, 0}, {0, 1}, {2, -3}, {-4, 5}}
+	for _, o := range append(AllOrientations(), Orientation{Rot180 + 4, true}) {
+		for _, c := range cs {
+			if got, want := o.Transform(c), transformSlow(o, c); got != want {
+				t.Errorf("%v.Transform(%v): got %v, want %v", o, c, got, want)
+			}
+		}
+	}
+}
+
+func TestOrientationMatrix(t *testing.T) {
+	testCases := []struct {
+		o    Orientation
+		want Matrix
+	}{
+		{Orientation{Rot0, false}, Matrix{{1, 0}, {0, 1}}},
+		{Orientation{Rot90, false}, Matrix{{0, 1}, {-1, 0}}},
+		{Orientation{Rot0, true}, Matrix{{1, 0}, {0, -1}}},
+	}
+	for _, tc := range testCases {
+		if got := tc.o.Matrix(); got != tc.want {
+			t.Errorf("%v.Matrix(): got %v, want %v", tc.o, got, tc.want)
+		}
+	}
+	for _, o := range AllOrientations() {
+		got, err := OrientationFromMatrix(o.Matrix())
+		if err != nil {
+			t.Fatalf("OrientationFromMatrix(%v): got %v, want no error", o.Matrix(), err)
+		}
+		if got != o {
+			t.Errorf("OrientationFromMatrix(%v): got %v, want %v", o.Matrix(), got, o)
+		}
+	}
+	if _, err := OrientationFromMatrix(Matrix{{2, 0}, {0, 1}}); err == nil {
+		t.Error("OrientationFromMatrix() with a scaling matrix: got no error, want error")
+	}
+}
+
+func TestOrientationCompose(t *testing.T) {
+	c := Coord{2, -1}
+	for _, o := range AllOrientations() {
+		for _, next := range AllOrientations() {
+			if got, want := o.Compose(next).Transform(c), next.Transform(o.Transform(c)); got != want {
+				t.Errorf("%v.Compose(%v).Transform(%v): got %v, want %v", o, next, c, got, want)
+			}
+		}
+	}
+}
+
+func TestOrientationInverse(t *testing.T) {
+	identity := Orientation{}
+	for _, o := range AllOrientations() {
+		if got := o.Compose(o.Inverse()); got != identity {
+			t.Errorf("%v.Compose(Inverse()): got %v, want %v", o, got, identity)
+		}
+	}
+	if got, want := (Orientation{Rot90, false}).Inverse(), (Orientation{Rot270, false}); got != want {
+		t.Errorf("Inverse(Rot90): got %v, want %v", got, want)
+	}
+	if got, want := (Orientation{Rot90, true}).Inverse(), (Orientation{Rot90, true}); got != want {
+		t.Errorf("Inverse(Rot90, flipped): got %v, want %v", got, want)
+	}
+}
+
+func TestOrientationEqual(t *testing.T) {
+	if !(Orientation{Rot90 + 4, true}).Equal(Orientation{Rot90, true}) {
+		t.Error("Equal() with unnormalized rotation: got false, want true")
+	}
+	if (Orientation{Rot90, true}).Equal(Orientation{Rot90, false}) {
+		t.Error("Equal() with different flip: got true, want false")
+	}
+}
+
+func TestOrientationControls(t *testing.T) {
+	o := Orientation{}
+	if got, want := o.RotateClockwise(), (Orientation{Rot90, false}); got != want {
+		t.Errorf("RotateClockwise(): got %v, want %v", got, want)
+	}
+	if got, want := o.RotateCounterClockwise(), (Orientation{Rot270, false}); got != want {
+		t.Errorf("RotateCounterClockwise(): got %v, want %v", got, want)
+	}
+	// Flipping then rotating clockwise looks like rotating counter-clockwise then flipping.
+	if got, want := o.FlipHorizontally().RotateClockwise(), (Orientation{Rot270, true}); got != want {
+		t.Errorf("FlipHorizontally().RotateClockwise(): got %v, want %v", got, want)
+	}
+	for _, o := range AllOrientations() {
+		if got := o.RotateClockwise().RotateCounterClockwise(); got != o {
+			t.Errorf("%v.RotateClockwise().RotateCounterClockwise(): got %v, want %v", o, got, o)
+		}
+		if got := o.FlipHorizontally().FlipHorizontally(); got != o {
+			t.Errorf("%v.FlipHorizontally() twice: got %v, want %v", o, got, o)
+		}
+	}
+}
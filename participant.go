@@ -0,0 +1,135 @@
+package blokus
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AddParticipant adds a person who plays one or more colors, with one player per seat in the given order.
+// With several seats, each player is named after the person and the color, e.g. "alice (blue)".
+// Seats with no color get the next free color. Either all seats are added, or none.
+func (g *Game) AddParticipant(name string, seats []Seat) error {
+	if err := checkName(name); err != nil {
+		return err
+	}
+	if len(seats) == 0 {
+		return fmt.Errorf("Participant %v needs at least one seat", name)
+	}
+	for _, p := range g.Players {
+		if !p.IsShared() && p.Participant() == name {
+			return fmt.Errorf("Participant %v already in the game", name)
+		}
+	}
+	numPlayers := len(g.Players)
+	for _, s := range seats {
+		if err := g.addSeat(name, s.Color, s.StartPos, len(seats) > 1); err != nil {
+			g.Players = g.Players[:numPlayers]
+			return err
+		}
+	}
+	return nil
+}
+
+// AddParticipantColors is like AddParticipant, with each color starting from a free corner.
+// As in TwoPlayerVariant, the colors take diagonally opposite corners where they can.
+func (g *Game) AddParticipantColors(name string, colors []Color) error {
+	corners := g.pairedCorners()
+	if len(colors) > len(corners) {
+		return fmt.Errorf("Not enough free corners for %d colors", len(colors))
+	}
+	seats := make([]Seat, 0, len(colors))
+	for i, c := range colors {
+		seats = append(seats, Seat{Color: c, StartPos: corners[i]})
+	}
+	return g.AddParticipant(name, seats)
+}
+
+// pairedCorners returns the free corners, with the pairs of diagonally opposite corners first.
+func (g *Game) pairedCorners() []Coord {
+	free := g.freeCorners()
+	var paired, single []Coord
+	for _, c := range free {
+		opposite := Coord{g.Board.Height - 1 - c.X, g.Board.Width - 1 - c.Y}
+		switch {
+		case !containsCoord(free, opposite):
+			single = append(single, c)
+		case !containsCoord(paired, c):
+			paired = append(paired, c, opposite)
+		}
+	}
+	return append(paired, single...)
+}
+
+func containsCoord(coords []Coord, c Coord) bool {
+	for _, cc := range coords {
+		if cc == c {
+			return true
+		}
+	}
+	return false
+}
+
+// addSeat adds a player for the participant. If the participant plays several colors,
+// the player is named after the color and owned by the participant.
+func (g *Game) addSeat(participant string, color Color, startPos Coord, multi bool) error {
+	if !multi {
+		return g.AddPlayer(participant, color, startPos)
+	}
+	if err := checkName(participant); err != nil {
+		return err
+	}
+	if color == colorEmpty {
+		var err error
+		color, err = g.GetNextFreeColor()
+		if err != nil {
+			return err
+		}
+	}
+	p, err := g.addPlayer(fmt.Sprintf("%s (%v)", participant, color), color, startPos)
+	if err != nil {
+		return err
+	}
+	p.Owner = participant
+	return nil
+}
+
+// Participants returns the names of the people playing the game, in the order of their first color.
+// People only sharing a color are not included.
+func (g *Game) Participants() []string {
+	var names []string
+	seen := map[string]bool{}
+	for _, p := range g.Players {
+		if p.IsShared() || seen[p.Participant()] {
+			continue
+		}
+		seen[p.Participant()] = true
+		names = append(names, p.Participant())
+	}
+	return names
+}
+
+// PlayersOf returns the players controlled by the participant, in player order.
+func (g *Game) PlayersOf(participant string) []*Player {
+	var ps []*Player
+	for _, p := range g.Players {
+		if !p.IsShared() && p.Participant() == participant {
+			ps = append(ps, p)
+		}
+	}
+	return ps
+}
+
+// OrderPlayersByColor sorts the players into the standard turn order of colors, i.e. blue, yellow, red, green,
+// so that people playing several colors don't play twice in a row. The first color moves first.
+// It's an error to call this after moves were played.
+func (g *Game) OrderPlayersByColor() error {
+	if len(g.Moves) > 0 {
+		return fmt.Errorf("Cannot reorder players after moves were played")
+	}
+	sort.SliceStable(g.Players, func(i, j int) bool {
+		return g.Players[i].Color < g.Players[j].Color
+	})
+	g.CurPlayerIndex = 0
+	g.FirstPlayerIndex = 0
+	return nil
+}
@@ -0,0 +1,161 @@
+package blokus
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddParticipant(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddParticipant("foo", []Seat{{Color: Blue, StartPos: Coord{0, 0}}, {Color: Red, StartPos: Coord{9, 9}}}); err != nil {
+		t.Fatalf("AddParticipant(foo): got %v, want no error", err)
+	}
+	if err := g.AddParticipant("bar", []Seat{{Color: Yellow, StartPos: Coord{0, 9}}}); err != nil {
+		t.Fatalf("AddParticipant(bar): got %v, want no error", err)
+	}
+	wantNames := []string{"foo (blue)", "foo (red)", "bar"}
+	for i, p := range g.Players {
+		if got, want := p.Name, wantNames[i]; got != want {
+			t.Errorf("Player %d name: got %v, want %v", i, got, want)
+		}
+	}
+	if got, want := g.Participants(), []string{"foo", "bar"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Participants(): got %v, want %v", got, want)
+	}
+	if got, want := g.PlayersOf("foo"), g.Players[:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("PlayersOf(foo): got %v, want %v", got, want)
+	}
+	if got, want := g.Controller(g.Players[1]), "foo"; got != want {
+		t.Errorf("Controller(foo (red)): got %v, want %v", got, want)
+	}
+}
+
+func TestAddParticipantDupeName(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddParticipant("foo", []Seat{{Color: Blue, StartPos: Coord{0, 0}}, {Color: Red, StartPos: Coord{9, 9}}}); err != nil {
+		t.Fatalf("AddParticipant(foo): got %v, want no error", err)
+	}
+	if err := g.AddParticipant("foo", []Seat{{Color: Yellow, StartPos: Coord{0, 9}}}); err == nil || !strings.Contains(err.Error(), "already") {
+		t.Errorf("AddParticipant(foo) again: got %v, want already in the game error", err)
+	}
+}
+
+func TestAddParticipantAddsAllOrNone(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddParticipant("foo", []Seat{{Color: Blue, StartPos: Coord{0, 0}}, {Color: Blue, StartPos: Coord{9, 9}}}); err == nil || !strings.Contains(err.Error(), "already in the game") {
+		t.Errorf("AddParticipant() with same color twice: got %v, want already in the game error", err)
+	}
+	if got := len(g.Players); got != 0 {
+		t.Errorf("Num players after failed AddParticipant(): got %v, want 0", got)
+	}
+}
+
+func TestOrderPlayersByColor(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddParticipant("foo", []Seat{{Color: Blue, StartPos: Coord{0, 0}}, {Color: Red, StartPos: Coord{9, 9}}}); err != nil {
+		t.Fatalf("AddParticipant(foo): got %v, want no error", err)
+	}
+	if err := g.AddParticipant("bar", []Seat{{Color: Yellow, StartPos: Coord{0, 9}}, {Color: Green, StartPos: Coord{9, 0}}}); err != nil {
+		t.Fatalf("AddParticipant(bar): got %v, want no error", err)
+	}
+	if err := g.OrderPlayersByColor(); err != nil {
+		t.Fatalf("OrderPlayersByColor(): got %v, want no error", err)
+	}
+	for i, want := range []Color{Blue, Yellow, Red, Green} {
+		if got := g.Players[i].Color; got != want {
+			t.Errorf("Player %d color: got %v, want %v", i, got, want)
+		}
+	}
+
+	playOrDie(t, g, Move{Player: g.CurrentPlayer(), PieceIndex: -1})
+	if err := g.OrderPlayersByColor(); err == nil {
+		t.Error("OrderPlayersByColor() after a move: got no error, want error")
+	}
+}
+
+func TestTwoPlayerVariantStandings(t *testing.T) {
+	g, err := TwoPlayerVariant().NewGame(DefaultPieces(), []string{"foo", "bar"})
+	if err != nil {
+		t.Fatalf("TwoPlayerVariant().NewGame(): got %v, want no error", err)
+	}
+	if got, want := len(g.Players), 4; got != want {
+		t.Fatalf("Num players: got %v, want %v", got, want)
+	}
+	for i, want := range []string{"foo", "bar", "foo", "bar"} {
+		if got := g.Players[i].Participant(); got != want {
+			t.Errorf("Player %d participant: got %v, want %v", i, got, want)
+		}
+	}
+	// Foo places the monomino with blue only.
+	playOrDie(t, g, Move{Player: g.CurrentPlayer(), PieceIndex: 0, Loc: Coord{0, 0}})
+
+	standings, err := g.Standings()
+	if err != nil {
+		t.Fatalf("Standings(): got %v, want no error", err)
+	}
+	if got, want := len(standings), 2; got != want {
+		t.Fatalf("Standings() len: got %v, want %v", got, want)
+	}
+	total := 0
+	for _, p := range DefaultPieces() {
+		total += len(p.Blocks)
+	}
+	if got, want := standings[0].Participant, "foo"; got != want {
+		t.Errorf("Standings()[0] participant: got %v, want %v", got, want)
+	}
+	if got, want := standings[0].Total, -2*total+1; got != want {
+		t.Errorf("Standings()[0] total: got %v, want %v", got, want)
+	}
+	if got, want := len(standings[0].Scores), 2; got != want {
+		t.Errorf("Standings()[0] num scores: got %v, want %v", got, want)
+	}
+	if got, want := standings[1].Total, -2*total; got != want {
+		t.Errorf("Standings()[1] total: got %v, want %v", got, want)
+	}
+}
+
+func TestAddParticipantColors(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddParticipantColors("foo", []Color{Blue, Red}); err != nil {
+		t.Fatalf("AddParticipantColors(foo): got %v, want no error", err)
+	}
+	// The colors start from diagonally opposite corners.
+	for i, want := range []Coord{{0, 0}, {9, 9}} {
+		if got := g.Players[i].StartPos; got != want {
+			t.Errorf("Player %d start position: got %v, want %v", i, got, want)
+		}
+	}
+	if err := g.AddParticipantColors("bar", []Color{Yellow, Green, Purple}); err == nil || !strings.Contains(err.Error(), "corners") {
+		t.Errorf("AddParticipantColors(bar) with too many colors: got %v, want not enough corners error", err)
+	}
+}
+
+func TestAddParticipantColorsOppositeCorners(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddPlayer("foo", Blue, Coord{9, 9}); err != nil {
+		t.Fatalf("AddPlayer(foo): got %v, want no error", err)
+	}
+	if err := g.AddParticipantColors("bar", []Color{Yellow, Red}); err != nil {
+		t.Fatalf("AddParticipantColors(bar): got %v, want no error", err)
+	}
+	// The corner opposite the first free one is taken, so bar gets the other diagonal.
+	for i, want := range []Coord{{9, 9}, {0, 9}, {9, 0}} {
+		if got := g.Players[i].StartPos; got != want {
+			t.Errorf("Player %d start position: got %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestAddPlayerWithParticipantName(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddParticipantColors("alice", []Color{Blue, Red}); err != nil {
+		t.Fatalf("AddParticipantColors(alice): got %v, want no error", err)
+	}
+	if err := g.AddPlayer("alice", Yellow, Coord{0, 9}); err == nil || !strings.Contains(err.Error(), "already in the game") {
+		t.Errorf("AddPlayer(alice) after alice's colors: got %v, want already in the game error", err)
+	}
+	if got, want := len(g.PlayersOf("alice")), 2; got != want {
+		t.Errorf("PlayersOf(alice): got %d players, want %d", got, want)
+	}
+}
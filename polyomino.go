@@ -0,0 +1,81 @@
+package blokus
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// Largest polyomino size that can be generated. There are 369 free octominoes.
+	maxPolyominoSize = 8
+)
+
+// Polyominoes returns the blocks of every free polyomino with the size, i.e. shapes of that many squares
+// connected by their edges, where shapes that match after rotating or flipping are counted once.
+// Each shape is in its canonical form, and the shapes are sorted.
+func Polyominoes(size int) ([][]Coord, error) {
+	if size < 1 || size > maxPolyominoSize {
+		return nil, fmt.Errorf("Polyomino size must be between 1 and %v. Provided: %v", maxPolyominoSize, size)
+	}
+	shapes := [][]Coord{{{0, 0}}}
+	for n := 1; n < size; n++ {
+		shapes = growPolyominoes(shapes)
+	}
+	return shapes, nil
+}
+
+// growPolyominoes returns the sorted, canonical shapes made by adding one square to any of the shapes.
+func growPolyominoes(shapes [][]Coord) [][]Coord {
+	seen := map[string]bool{}
+	var grown [][]Coord
+	for _, shape := range shapes {
+		blocks := map[Coord]bool{}
+		for _, b := range shape {
+			blocks[b] = true
+		}
+		for _, b := range shape {
+			for _, n := range neighbors {
+				n = Coord{b.X + n.X, b.Y + n.Y}
+				if blocks[n] {
+					continue
+				}
+				canonical := canonicalCoords(append(append([]Coord(nil), shape...), n))
+				key := fmt.Sprint(canonical)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				grown = append(grown, canonical)
+			}
+		}
+	}
+	sort.Slice(grown, func(i, j int) bool {
+		return lessCoords(grown[i], grown[j])
+	})
+	return grown
+}
+
+// PolyominoPieces returns a piece set with every free polyomino from the smallest to the largest size, inclusive.
+// Pieces are ordered by size, and pieces of the standard set are named, e.g. PolyominoPieces(1, 5)
+// has the same 21 shapes as DefaultPieces.
+func PolyominoPieces(minSize, maxSize int) ([]*Piece, error) {
+	if minSize > maxSize {
+		return nil, fmt.Errorf("Minimum polyomino size %v is larger than maximum size %v", minSize, maxSize)
+	}
+	var pieces []*Piece
+	for size := minSize; size <= maxSize; size++ {
+		shapes, err := Polyominoes(size)
+		if err != nil {
+			return nil, err
+		}
+		for _, shape := range shapes {
+			p, err := NewPiece(shape)
+			if err != nil {
+				return nil, err
+			}
+			p.Name = p.StandardName()
+			pieces = append(pieces, p)
+		}
+	}
+	return pieces, nil
+}
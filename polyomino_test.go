@@ -0,0 +1,68 @@
+package blokus
+
+import (
+	"testing"
+)
+
+func TestPolyominoes(t *testing.T) {
+	// Number of free polyominoes of each size, starting from 1.
+	want := []int{1, 1, 2, 5, 12, 35, 108}
+	for i, w := range want {
+		shapes, err := Polyominoes(i + 1)
+		if err != nil {
+			t.Fatalf("Polyominoes(%d): got error %v, want no error", i+1, err)
+		}
+		if got := len(shapes); got != w {
+			t.Errorf("Polyominoes(%d): got %d shapes, want %d", i+1, got, w)
+		}
+		for _, s := range shapes {
+			if err := (&Piece{Blocks: s}).Validate(); err != nil {
+				t.Errorf("Polyominoes(%d) shape %v: got invalid piece %v, want valid", i+1, s, err)
+			}
+		}
+	}
+	for _, size := range []int{0, maxPolyominoSize + 1} {
+		if _, err := Polyominoes(size); err == nil {
+			t.Errorf("Polyominoes(%d): got no error, want error", size)
+		}
+	}
+}
+
+func TestPolyominoPieces(t *testing.T) {
+	pieces, err := PolyominoPieces(1, 5)
+	if err != nil {
+		t.Fatalf("PolyominoPieces(1, 5): got error %v, want no error", err)
+	}
+	defaults := DefaultPieces()
+	if got, want := len(pieces), len(defaults); got != want {
+		t.Fatalf("PolyominoPieces(1, 5): got %d pieces, want %d", got, want)
+	}
+	for _, d := range defaults {
+		found := false
+		for _, p := range pieces {
+			if p.SameShape(d) {
+				found = true
+				if got, want := p.Name, d.Name; got != want {
+					t.Errorf("Name of piece with shape of %v: got %q, want %q", d.Name, got, want)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("PolyominoPieces(1, 5): got no piece with shape of %v, want one", d.Name)
+		}
+	}
+	if err := ValidatePieces(pieces); err != nil {
+		t.Errorf("ValidatePieces(PolyominoPieces(1, 5)): got error %v, want no error", err)
+	}
+
+	hexominoes, err := PolyominoPieces(6, 6)
+	if err != nil {
+		t.Fatalf("PolyominoPieces(6, 6): got error %v, want no error", err)
+	}
+	if _, err := NewGame(DefaultBoardSize, hexominoes); err != nil {
+		t.Errorf("NewGame() with hexominoes: got error %v, want no error", err)
+	}
+	if _, err := PolyominoPieces(3, 2); err == nil {
+		t.Error("PolyominoPieces(3, 2): got no error, want error")
+	}
+}
@@ -0,0 +1,433 @@
+package blokus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// A game record is a text file in the style of PGN. It starts with tag pairs like [Date "2026.10.17"],
+// one per line, followed by a blank line and the moves in move notation, one per line and optionally numbered:
+//
+//	[Date "2026.10.17"]
+//	[BoardSize "20"]
+//	[Pieces "I1 I2 I3 ..."]
+//	[Players "B Y"]
+//	[Blue "alice"]
+//	[BlueStart "0,0"]
+//	...
+//
+//	1. B I1 r0 @ 0,0
+//	2. Y pass
+//
+// Pieces are listed by standard name, or by their blocks like "0,0;1,0;1,1", optionally prefixed with a name
+// and "=". Lines starting with ';' are comments.
+
+const (
+	// resultOngoing is the result of a game that has not ended.
+	resultOngoing = "*"
+)
+
+// Names of the tags that describe how the game was set up. Each player has tags named after its color,
+// e.g. "Blue" for the name, and "BlueStart", "BlueOwner" and "BlueSharedBy" for the other settings.
+const (
+	tagDate        = "Date"
+	tagResult      = "Result"
+	tagVariant     = "Variant"
+	tagBoardSize   = "BoardSize"
+	tagPieces      = "Pieces"
+	tagPlayers     = "Players"
+	tagFirstPlayer = "FirstPlayer"
+	tagForbidPass  = "ForbidVoluntaryPass"
+
+	tagSuffixStart    = "Start"
+	tagSuffixOwner    = "Owner"
+	tagSuffixSharedBy = "SharedBy"
+)
+
+// Record is a game as archived in a game record.
+type Record struct {
+	// Tags are other metadata, e.g. "Event", "Site" or "Round". They're written in sorted order.
+	Tags map[string]string
+	// Date the game was played, e.g. "2026.10.17".
+	Date string
+	// Result is the winners separated by ", ", or "*" if the game has not ended.
+	Result string
+	// Setup is how the game was set up before the first move.
+	Setup *GameSetup
+	// Moves are the moves played, in order.
+	Moves []Move
+}
+
+// Record returns the record of the game, with the result filled in if the game ended.
+func (g *Game) Record() (*Record, error) {
+	r := &Record{
+		Result: resultOngoing,
+		Setup:  g.Setup(),
+	}
+	for _, m := range g.Moves {
+		r.Moves = append(r.Moves, *m)
+	}
+	if g.IsGameEnd() {
+		winners, err := g.Winners()
+		if err != nil {
+			return nil, err
+		}
+		r.Result = strings.Join(winners, ", ")
+	}
+	return r, nil
+}
+
+// Replay creates the game of the record by replaying its moves. See Replay.
+func (r *Record) Replay() (*Game, error) {
+	return Replay(r.Setup, r.Moves)
+}
+
+// WriteRecord writes the record in the game record format.
+func WriteRecord(w io.Writer, r *Record) error {
+	if r.Setup == nil {
+		return fmt.Errorf("Record has no game setup")
+	}
+	g, err := r.Setup.NewGame()
+	if err != nil {
+		return fmt.Errorf("Could not create game from setup: %v", err)
+	}
+
+	tags, err := r.tags(g)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	for _, t := range tags {
+		fmt.Fprintf(bw, "[%s %s]\n", t[0], strconv.Quote(t[1]))
+	}
+	fmt.Fprintln(bw)
+	for i, m := range r.Moves {
+		if m.Player == nil {
+			return fmt.Errorf("Move %d has no player", i)
+		}
+		fmt.Fprintf(bw, "%d. %s\n", i+1, g.FormatMove(m))
+	}
+	return bw.Flush()
+}
+
+// tags returns the tag pairs of the record in the order they're written.
+func (r *Record) tags(g *Game) ([][2]string, error) {
+	var tags [][2]string
+	add := func(name, value string) {
+		tags = append(tags, [2]string{name, value})
+	}
+
+	var keys []string
+	for k := range r.Tags {
+		if isSetupTag(k) {
+			return nil, fmt.Errorf("Tag %v is reserved for the game setup", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		add(k, r.Tags[k])
+	}
+	if len(r.Date) > 0 {
+		add(tagDate, r.Date)
+	}
+	result := r.Result
+	if len(result) == 0 {
+		result = resultOngoing
+	}
+	add(tagResult, result)
+
+	s := r.Setup
+	if len(s.Variant) > 0 {
+		add(tagVariant, s.Variant)
+	}
+	add(tagBoardSize, strconv.Itoa(s.BoardSize))
+	add(tagPieces, formatPieces(s.Pieces))
+	var letters []string
+	for _, p := range g.Players {
+		letters = append(letters, p.Color.Letter())
+	}
+	add(tagPlayers, strings.Join(letters, " "))
+	if len(g.Players) > 0 {
+		add(tagFirstPlayer, g.Players[s.FirstPlayerIndex].Color.Letter())
+	}
+	if s.ForbidVoluntaryPass {
+		add(tagForbidPass, "true")
+	}
+	for _, p := range s.Players {
+		prefix := colorTagPrefix(p.Color)
+		add(prefix, p.Name)
+		add(prefix+tagSuffixStart, fmt.Sprintf("%d,%d", p.StartPos.X, p.StartPos.Y))
+		if len(p.Owner) > 0 {
+			add(prefix+tagSuffixOwner, p.Owner)
+		}
+		if len(p.SharedBy) > 0 {
+			for _, name := range p.SharedBy {
+				if strings.Contains(name, ",") {
+					return nil, fmt.Errorf("Name of who shares the color cannot contain ',': %q", name)
+				}
+			}
+			add(prefix+tagSuffixSharedBy, strings.Join(p.SharedBy, ", "))
+		}
+	}
+	return tags, nil
+}
+
+func isSetupTag(name string) bool {
+	switch name {
+	case tagDate, tagResult, tagVariant, tagBoardSize, tagPieces, tagPlayers, tagFirstPlayer, tagForbidPass:
+		return true
+	}
+	_, _, ok := parseColorTag(name)
+	return ok
+}
+
+// colorTagPrefix returns the color's name capitalized, e.g. "Blue".
+func colorTagPrefix(c Color) string {
+	s := c.String()
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// parseColorTag splits a tag name like "BlueStart" into the color and the suffix.
+func parseColorTag(name string) (Color, string, bool) {
+	for c := Blue; c < colorEnd; c++ {
+		prefix := colorTagPrefix(c)
+		if !strings.HasPrefix(name, prefix) {
+			continue
+		}
+		switch suffix := strings.TrimPrefix(name, prefix); suffix {
+		case "", tagSuffixStart, tagSuffixOwner, tagSuffixSharedBy:
+			return c, suffix, true
+		}
+	}
+	return colorEmpty, "", false
+}
+
+// formatPieces lists the pieces by standard name, or by blocks for pieces not in the standard set.
+func formatPieces(pieces []*Piece) string {
+	var entries []string
+	for _, p := range pieces {
+		if sp, err := NewStandardPiece(p.Name); err == nil && p.Name == sp.Name && equalCoords(p.Blocks, sp.Blocks) {
+			entries = append(entries, p.Name)
+			continue
+		}
+		var blocks []string
+		for _, b := range p.Blocks {
+			blocks = append(blocks, fmt.Sprintf("%d,%d", b.X, b.Y))
+		}
+		entry := strings.Join(blocks, ";")
+		if len(p.Name) > 0 {
+			entry = p.Name + "=" + entry
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, " ")
+}
+
+func parsePieces(s string) ([]*Piece, error) {
+	var pieces []*Piece
+	for _, entry := range strings.Fields(s) {
+		if !strings.Contains(entry, ",") {
+			p, err := NewStandardPiece(entry)
+			if err != nil {
+				return nil, err
+			}
+			pieces = append(pieces, p)
+			continue
+		}
+		var name string
+		if i := strings.Index(entry, "="); i >= 0 {
+			name, entry = entry[:i], entry[i+1:]
+		}
+		var blocks []Coord
+		for _, b := range strings.Split(entry, ";") {
+			c, err := parseCoord(b)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid piece block: %v", err)
+			}
+			blocks = append(blocks, c)
+		}
+		p, err := NewPiece(blocks)
+		if err != nil {
+			return nil, err
+		}
+		p.Name = name
+		pieces = append(pieces, p)
+	}
+	return pieces, nil
+}
+
+// ReadRecord reads a record in the game record format. Moves are checked for syntax, but not replayed.
+func ReadRecord(rd io.Reader) (*Record, error) {
+	r := &Record{
+		Tags:  map[string]string{},
+		Setup: &GameSetup{},
+	}
+	tags := map[string]string{}
+	var moveLines []string
+	var moveLineNums []int
+
+	scanner := bufio.NewScanner(rd)
+	lineNum := 0
+	inMoves := false
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if !inMoves && strings.HasPrefix(line, "[") {
+			name, value, err := parseTag(line)
+			if err != nil {
+				return nil, fmt.Errorf("Line %d: %v", lineNum, err)
+			}
+			if _, ok := tags[name]; ok {
+				return nil, fmt.Errorf("Line %d: duplicate tag %v", lineNum, name)
+			}
+			tags[name] = value
+			continue
+		}
+		inMoves = true
+		moveLines = append(moveLines, line)
+		moveLineNums = append(moveLineNums, lineNum)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.parseTags(tags); err != nil {
+		return nil, err
+	}
+	g, err := r.Setup.NewGame()
+	if err != nil {
+		return nil, fmt.Errorf("Could not create game from setup: %v", err)
+	}
+	for i, line := range moveLines {
+		fields := strings.Fields(line)
+		if strings.HasSuffix(fields[0], ".") {
+			if _, err := strconv.Atoi(strings.TrimSuffix(fields[0], ".")); err == nil {
+				line = strings.Join(fields[1:], " ")
+				fields = fields[1:]
+			}
+		}
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("Line %d: move is empty", moveLineNums[i])
+		}
+		if _, ok := colorFromLetter(fields[0]); !ok {
+			return nil, fmt.Errorf("Line %d: move must start with a color letter: %q", moveLineNums[i], line)
+		}
+		m, err := g.ParseMove(line)
+		if err != nil {
+			return nil, fmt.Errorf("Line %d: %v", moveLineNums[i], err)
+		}
+		r.Moves = append(r.Moves, m)
+	}
+	return r, nil
+}
+
+// parseTag parses a line like [Name "value"].
+func parseTag(line string) (string, string, error) {
+	if !strings.HasSuffix(line, "]") {
+		return "", "", fmt.Errorf("Tag must end with ']': %q", line)
+	}
+	line = strings.TrimSpace(line[1 : len(line)-1])
+	i := strings.IndexAny(line, " \t")
+	if i <= 0 {
+		return "", "", fmt.Errorf("Tag must have a name and a quoted value: %q", line)
+	}
+	value, err := strconv.Unquote(strings.TrimSpace(line[i:]))
+	if err != nil {
+		return "", "", fmt.Errorf("Tag value must be quoted: %q", line)
+	}
+	return line[:i], value, nil
+}
+
+// parseTags fills in the record from the tag pairs.
+func (r *Record) parseTags(tags map[string]string) error {
+	s := r.Setup
+	var err error
+	players := map[Color]*PlayerSetup{}
+	for name, value := range tags {
+		switch name {
+		case tagDate:
+			r.Date = value
+		case tagResult:
+			r.Result = value
+		case tagVariant:
+			s.Variant = value
+		case tagBoardSize:
+			if s.BoardSize, err = strconv.Atoi(value); err != nil {
+				return fmt.Errorf("Invalid board size: %q", value)
+			}
+		case tagPieces:
+			if s.Pieces, err = parsePieces(value); err != nil {
+				return err
+			}
+		case tagPlayers, tagFirstPlayer:
+			// Handled below, once all players are known.
+		case tagForbidPass:
+			if s.ForbidVoluntaryPass, err = strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("Invalid value of %v: %q", tagForbidPass, value)
+			}
+		default:
+			c, suffix, ok := parseColorTag(name)
+			if !ok {
+				r.Tags[name] = value
+				continue
+			}
+			p, ok := players[c]
+			if !ok {
+				p = &PlayerSetup{Color: c}
+				players[c] = p
+			}
+			switch suffix {
+			case "":
+				p.Name = value
+			case tagSuffixStart:
+				if p.StartPos, err = parseCoord(value); err != nil {
+					return err
+				}
+			case tagSuffixOwner:
+				p.Owner = value
+			case tagSuffixSharedBy:
+				for _, n := range strings.Split(value, ",") {
+					p.SharedBy = append(p.SharedBy, strings.TrimSpace(n))
+				}
+			}
+		}
+	}
+
+	for _, letter := range strings.Fields(tags[tagPlayers]) {
+		c, ok := colorFromLetter(letter)
+		if !ok {
+			return fmt.Errorf("Unknown color in %v: %q", tagPlayers, letter)
+		}
+		p, ok := players[c]
+		if !ok {
+			return fmt.Errorf("No tags for player with color %v", c)
+		}
+		s.Players = append(s.Players, *p)
+		delete(players, c)
+	}
+	for c := range players {
+		return fmt.Errorf("Player with color %v is not in %v", c, tagPlayers)
+	}
+	if first, ok := tags[tagFirstPlayer]; ok {
+		c, ok := colorFromLetter(first)
+		found := false
+		for i, p := range s.Players {
+			if ok && p.Color == c {
+				s.FirstPlayerIndex = i
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("First player is not in %v: %q", tagPlayers, first)
+		}
+	}
+	return nil
+}
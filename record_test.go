@@ -0,0 +1,187 @@
+package blokus
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func playRandomly(t *testing.T, g *Game, turns int) {
+	for i := 0; i < turns && !g.IsGameEnd(); i++ {
+		moves := g.LegalMoves(g.CurrentPlayer())
+		m := Move{Player: g.CurrentPlayer(), PieceIndex: -1}
+		if len(moves) > 0 {
+			m = moves[(i*7)%len(moves)]
+		}
+		if _, err := g.Play(m); err != nil {
+			t.Fatalf("Play(%v): got %v, want no error", m, err)
+		}
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	for _, v := range []*Variant{ClassicVariant(), TwoPlayerVariant(), ThreePlayerVariant(), DuoVariant()} {
+		names := []string{"alice", "bob", "carol", "dave"}[:v.NumPlayers()]
+		g, err := v.NewGame(DefaultPieces(), names)
+		if err != nil {
+			t.Fatalf("NewGame(%v): got %v, want no error", v.Name, err)
+		}
+		playRandomly(t, g, 200)
+
+		r, err := g.Record()
+		if err != nil {
+			t.Fatalf("Record(): got %v, want no error", err)
+		}
+		r.Date = "2026.10.17"
+		r.Tags = map[string]string{"Event": "Club \"night\"", "Round": "3"}
+		var buf bytes.Buffer
+		if err := WriteRecord(&buf, r); err != nil {
+			t.Fatalf("WriteRecord(): got %v, want no error", err)
+		}
+		got, err := ReadRecord(&buf)
+		if err != nil {
+			t.Fatalf("ReadRecord() of %v game: got %v, want no error", v.Name, err)
+		}
+		if !reflect.DeepEqual(got.Tags, r.Tags) {
+			t.Errorf("Record tags: got %v, want %v", got.Tags, r.Tags)
+		}
+		if got.Date != r.Date || got.Result != r.Result {
+			t.Errorf("Record date and result: got %q %q, want %q %q", got.Date, got.Result, r.Date, r.Result)
+		}
+		if got, want := len(got.Moves), len(g.Moves); got != want {
+			t.Fatalf("Record moves len: got %d, want %d", got, want)
+		}
+		replayed, err := got.Replay()
+		if err != nil {
+			t.Fatalf("Replay() of %v game: got %v, want no error", v.Name, err)
+		}
+		if !reflect.DeepEqual(replayed.Board.Grid, g.Board.Grid) {
+			t.Errorf("Replayed board of %v game: got different board, want same board", v.Name)
+		}
+		if !reflect.DeepEqual(replayed.Setup().Players, g.Setup().Players) {
+			t.Errorf("Replayed players of %v game: got %v, want %v", v.Name, replayed.Setup().Players, g.Setup().Players)
+		}
+		if got, want := replayed.FirstPlayerIndex, g.FirstPlayerIndex; got != want {
+			t.Errorf("Replayed first player of %v game: got %v, want %v", v.Name, got, want)
+		}
+		if got, want := replayed.Variant, v.Name; got != want {
+			t.Errorf("Replayed variant: got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestRecordCustomPieces(t *testing.T) {
+	f5, err := NewStandardPiece("F5")
+	if err != nil {
+		t.Fatalf("NewStandardPiece(F5): got %v, want no error", err)
+	}
+	pieces := []*Piece{
+		f5,
+		newPieceOrDie(t, []Coord{{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}, {5, 0}}),
+		newPieceOrDie(t, []Coord{{0, 0}, {0, 1}}),
+	}
+	pieces[2].Name = "domino"
+	g, err := NewGame(10, pieces)
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	if err := g.AddPlayer("alice", Blue, Coord{0, 0}); err != nil {
+		t.Fatalf("AddPlayer(): got %v, want no error", err)
+	}
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 1, Loc: Coord{0, 0}})
+
+	r, err := g.Record()
+	if err != nil {
+		t.Fatalf("Record(): got %v, want no error", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteRecord(&buf, r); err != nil {
+		t.Fatalf("WriteRecord(): got %v, want no error", err)
+	}
+	if want := `[Pieces "F5 0,0;1,0;2,0;3,0;4,0;5,0 domino=0,0;0,1"]`; !strings.Contains(buf.String(), want) {
+		t.Errorf("WriteRecord(): got %q, want it to contain %q", buf.String(), want)
+	}
+	got, err := ReadRecord(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecord(): got %v, want no error", err)
+	}
+	for i, p := range got.Setup.Pieces {
+		if p.Name != pieces[i].Name || !reflect.DeepEqual(p.Blocks, pieces[i].Blocks) {
+			t.Errorf("Piece %d: got %v %v, want %v %v", i, p.Name, p.Blocks, pieces[i].Name, pieces[i].Blocks)
+		}
+	}
+	if got, want := got.Moves[0].String(), "B 1 r0 @ 0,0"; got != want {
+		t.Errorf("Move 0: got %q, want %q", got, want)
+	}
+}
+
+func TestReadRecord(t *testing.T) {
+	text := `; A game record written by hand.
+[Event "Test"]
+[BoardSize "14"]
+[Pieces "I1 I2"]
+[Players "P O"]
+[FirstPlayer "O"]
+[Purple "ann"]
+[PurpleStart "4,4"]
+[Orange "bob"]
+[OrangeStart "9,9"]
+
+1. O I2 r1 @ 9,9
+P i1 @ 4,4
+`
+	r, err := ReadRecord(strings.NewReader(text))
+	if err != nil {
+		t.Fatalf("ReadRecord(): got %v, want no error", err)
+	}
+	if got, want := r.Tags["Event"], "Test"; got != want {
+		t.Errorf("Event tag: got %q, want %q", got, want)
+	}
+	if got, want := r.Setup.FirstPlayerIndex, 1; got != want {
+		t.Errorf("First player index: got %v, want %v", got, want)
+	}
+	g, err := r.Replay()
+	if err != nil {
+		t.Fatalf("Replay(): got %v, want no error", err)
+	}
+	for _, c := range []Coord{{9, 9}, {9, 8}, {4, 4}} {
+		if got := g.Board.Cell(c); !got.IsColored() {
+			t.Errorf("Cell %v: got %v, want colored", c, got)
+		}
+	}
+}
+
+func TestReadRecordErrors(t *testing.T) {
+	header := "[BoardSize \"14\"]\n[Pieces \"I1\"]\n[Players \"P\"]\n[Purple \"ann\"]\n[PurpleStart \"4,4\"]\n\n"
+	for _, text := range []string{
+		"[BoardSize 14]\n",
+		"[BoardSize \"14\"\n",
+		"[BoardSize \"14\"]\n[BoardSize \"14\"]\n",
+		"[Pieces \"Q7\"]\n",
+		"[Players \"P\"]\n",
+		"[Players \"P\"]\n[Purple \"ann\"]\n[FirstPlayer \"O\"]\n",
+		"[Orange \"bob\"]\n",
+		header + "I1 @ 4,4\n",
+		header + "P X5 @ 4,4\n",
+	} {
+		if _, err := ReadRecord(strings.NewReader(text)); err == nil {
+			t.Errorf("ReadRecord(%q): got no error, want error", text)
+		}
+	}
+}
+
+func TestWriteRecordErrors(t *testing.T) {
+	g := newMidGame(t, 0)
+	r, err := g.Record()
+	if err != nil {
+		t.Fatalf("Record(): got %v, want no error", err)
+	}
+	r.Tags = map[string]string{"BlueStart": "1,1"}
+	if err := WriteRecord(&bytes.Buffer{}, r); err == nil {
+		t.Error("WriteRecord() with reserved tag: got no error, want error")
+	}
+	if err := WriteRecord(&bytes.Buffer{}, &Record{}); err == nil {
+		t.Error("WriteRecord() with no setup: got no error, want error")
+	}
+}
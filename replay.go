@@ -0,0 +1,245 @@
+package blokus
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// PlayerSetup describes a player as added to a game before any move.
+type PlayerSetup struct {
+	Name     string
+	Color    Color
+	StartPos Coord
+	// SharedBy is set for a shared color. See Player.SharedBy.
+	SharedBy []string
+	// Owner is set for a person playing several colors. See Player.Owner.
+	Owner string
+}
+
+// GameSetup contains the initial settings of a game, from which the game can be recreated.
+type GameSetup struct {
+	// BoardSize is the length of one edge of the square board.
+	BoardSize int
+	// Pieces is the set of pieces every player starts with.
+	Pieces []*Piece
+	// Players in turn order.
+	Players []PlayerSetup
+	// FirstPlayerIndex is the index of the player who moves first.
+	FirstPlayerIndex int
+	// Variant is the name of the rules the game was set up with, if any.
+	Variant string
+	// ForbidVoluntaryPass is copied to the game.
+	ForbidVoluntaryPass bool
+}
+
+// NewGame creates a game from the setup with no moves played.
+func (s *GameSetup) NewGame() (*Game, error) {
+	g, err := NewGame(s.BoardSize, s.Pieces)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range s.Players {
+		if len(p.SharedBy) > 0 {
+			err = g.AddSharedPlayer(p.Color, p.StartPos, p.SharedBy)
+		} else {
+			var player *Player
+			if player, err = g.addPlayer(p.Name, p.Color, p.StartPos); err == nil {
+				player.Owner = p.Owner
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(s.Players) > 0 && (s.FirstPlayerIndex < 0 || s.FirstPlayerIndex >= len(s.Players)) {
+		return nil, fmt.Errorf("First player index out of range: %d", s.FirstPlayerIndex)
+	}
+	g.FirstPlayerIndex = s.FirstPlayerIndex
+	g.CurPlayerIndex = s.FirstPlayerIndex
+	g.Variant = s.Variant
+	g.ForbidVoluntaryPass = s.ForbidVoluntaryPass
+	return g, nil
+}
+
+// Setup returns the initial settings of the game.
+func (g *Game) Setup() *GameSetup {
+	s := &GameSetup{
+		BoardSize:           g.Board.Height,
+		Pieces:              g.Pieces,
+		FirstPlayerIndex:    g.FirstPlayerIndex,
+		Variant:             g.Variant,
+		ForbidVoluntaryPass: g.ForbidVoluntaryPass,
+	}
+	for _, p := range g.Players {
+		s.Players = append(s.Players, PlayerSetup{
+			Name:     p.Name,
+			Color:    p.Color,
+			StartPos: p.StartPos,
+			SharedBy: p.SharedBy,
+			Owner:    p.Owner,
+		})
+	}
+	return s
+}
+
+// ReplayError is returned when a move can't be replayed.
+type ReplayError struct {
+	// Index of the first illegal move.
+	Index int
+	Move  Move
+	Err   error
+}
+
+func (e *ReplayError) Error() string {
+	return fmt.Sprintf("Illegal move at index %d: %v", e.Index, e.Err)
+}
+
+func (e *ReplayError) Unwrap() error {
+	return e.Err
+}
+
+// Replay creates a game from the setup and plays the moves in order with the normal rules.
+// Moves may come from another game, since players are matched by color.
+// If a move is illegal, the returned error is a *ReplayError with the index of the move.
+func Replay(setup *GameSetup, moves []Move) (*Game, error) {
+	r, err := NewReplayer(setup, moves)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.Seek(r.Len()); err != nil {
+		return nil, err
+	}
+	return r.Game(), nil
+}
+
+// Verify replays the moves of the game from its initial settings,
+// and checks that the resulting board matches the current board.
+func (g *Game) Verify() error {
+	moves := make([]Move, 0, len(g.Moves))
+	for _, m := range g.Moves {
+		moves = append(moves, *m)
+	}
+	replayed, err := Replay(g.Setup(), moves)
+	if err != nil {
+		return err
+	}
+	for x := 0; x < g.Board.Height; x++ {
+		if !reflect.DeepEqual(g.Board.Row(x), replayed.Board.Row(x)) {
+			return fmt.Errorf("Board row %d doesn't match the moves", x)
+		}
+	}
+	return nil
+}
+
+// Replayer steps through the positions of a game, one move at a time.
+type Replayer struct {
+	moves []Move
+	game  *Game
+}
+
+// NewReplayer creates a replayer at the initial position of the game.
+func NewReplayer(setup *GameSetup, moves []Move) (*Replayer, error) {
+	if setup == nil {
+		return nil, fmt.Errorf("Game setup cannot be nil")
+	}
+	g, err := setup.NewGame()
+	if err != nil {
+		return nil, fmt.Errorf("Could not create game from setup: %v", err)
+	}
+	return &Replayer{
+		moves: moves,
+		game:  g,
+	}, nil
+}
+
+// Game returns the game at the current position. It should not be modified.
+func (r *Replayer) Game() *Game {
+	return r.game
+}
+
+// Len returns the number of moves to replay.
+func (r *Replayer) Len() int {
+	return len(r.moves)
+}
+
+// Position returns the number of moves played so far.
+func (r *Replayer) Position() int {
+	return len(r.game.Moves)
+}
+
+// Next plays the next move.
+func (r *Replayer) Next() error {
+	i := r.Position()
+	if i >= len(r.moves) {
+		return fmt.Errorf("No more moves to replay")
+	}
+	m := r.moves[i]
+	if m.Player == nil {
+		return &ReplayError{Index: i, Move: m, Err: fmt.Errorf("Invalid player")}
+	}
+	p := r.game.playerByColor(m.Player.Color)
+	if p == nil {
+		return &ReplayError{Index: i, Move: m, Err: fmt.Errorf("No player with color %v", m.Player.Color)}
+	}
+	m.Player = p
+	if _, err := r.game.Play(m); err != nil {
+		return &ReplayError{Index: i, Move: r.moves[i], Err: err}
+	}
+	return nil
+}
+
+// Prev takes back the last played move.
+func (r *Replayer) Prev() error {
+	if r.Position() == 0 {
+		return fmt.Errorf("Already at the initial position")
+	}
+	_, err := r.game.Undo()
+	return err
+}
+
+// Seek moves to the position after the given number of moves.
+func (r *Replayer) Seek(pos int) error {
+	if pos < 0 || pos > len(r.moves) {
+		return fmt.Errorf("Position out of range: %d", pos)
+	}
+	for r.Position() > pos {
+		if err := r.Prev(); err != nil {
+			return err
+		}
+	}
+	for r.Position() < pos {
+		if err := r.Next(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BindMovePlayers points the players of the moves and redos to the game's players with the same color.
+// Games loaded from datastore have copies of the players in their moves, which the game's methods
+// don't take for its own players, so this should be called after loading.
+func (g *Game) BindMovePlayers() error {
+	for _, moves := range [][]*Move{g.Moves, g.Redos} {
+		for i, m := range moves {
+			if m.Player == nil {
+				return fmt.Errorf("Move %d has no player", i)
+			}
+			p := g.playerByColor(m.Player.Color)
+			if p == nil {
+				return fmt.Errorf("Move %d has color %v of no player in the game", i, m.Player.Color)
+			}
+			m.Player = p
+		}
+	}
+	return nil
+}
+
+// playerByColor returns the player with the color, or nil if not found.
+func (g *Game) playerByColor(color Color) *Player {
+	for _, p := range g.Players {
+		if p.Color == color {
+			return p
+		}
+	}
+	return nil
+}
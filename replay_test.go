@@ -0,0 +1,242 @@
+package blokus
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetupNewGame(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	g.ForbidVoluntaryPass = true
+	got, err := g.Setup().NewGame()
+	if err != nil {
+		t.Fatalf("Setup().NewGame(): got %v, want no error", err)
+	}
+	if !reflect.DeepEqual(got, g) {
+		t.Errorf("Setup().NewGame(): got %+v, want %+v", got, g)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 0, Loc: Coord{0, 0}})
+	playOrDie(t, g, Move{Player: g.Players[1], PieceIndex: -1})
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 1, Loc: Coord{3, 1}})
+
+	// Moves with players from another game are matched by color.
+	other := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	moves := []Move{
+		{Player: other.Players[0], PieceIndex: 0, Loc: Coord{0, 0}},
+		{Player: other.Players[1], PieceIndex: -1},
+		{Player: other.Players[0], PieceIndex: 1, Loc: Coord{3, 1}},
+	}
+	got, err := Replay(g.Setup(), moves)
+	if err != nil {
+		t.Fatalf("Replay(): got %v, want no error", err)
+	}
+	if !reflect.DeepEqual(got.Board.Grid, g.Board.Grid) {
+		t.Errorf("Replay() board: got %v, want %v", got.Board.Grid, g.Board.Grid)
+	}
+	if got, want := got.CurPlayerIndex, g.CurPlayerIndex; got != want {
+		t.Errorf("Replay() player turn: got %v, want %v", got, want)
+	}
+	if got.Moves[0].Player != got.Players[0] {
+		t.Errorf("Replay() move player: got %v, want player of the replayed game", got.Moves[0].Player)
+	}
+}
+
+func TestReplayIllegalMove(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	moves := []Move{
+		{Player: g.Players[0], PieceIndex: 0, Loc: Coord{0, 0}},
+		{Player: g.Players[1], PieceIndex: 0, Loc: Coord{5, 5}},
+	}
+	_, err := Replay(g.Setup(), moves)
+	re, ok := err.(*ReplayError)
+	if !ok {
+		t.Fatalf("Replay() with illegal move: got %v, want *ReplayError", err)
+	}
+	if got, want := re.Index, 1; got != want {
+		t.Errorf("ReplayError index: got %v, want %v", got, want)
+	}
+}
+
+func TestReplayUnknownColor(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	p, err := NewPlayer("baz", Green, Coord{0, 9}, 2)
+	if err != nil {
+		t.Fatalf("NewPlayer(): got %v, want no error", err)
+	}
+	if _, err := Replay(g.Setup(), []Move{{Player: p, PieceIndex: -1}}); err == nil || !strings.Contains(err.Error(), "green") {
+		t.Errorf("Replay() with unknown player color: got %v, want error about green", err)
+	}
+}
+
+func TestReplayerSeek(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	moves := []Move{
+		{Player: g.Players[0], PieceIndex: 0, Loc: Coord{0, 0}},
+		{Player: g.Players[1], PieceIndex: 0, Loc: Coord{7, 9}},
+		{Player: g.Players[0], PieceIndex: -1},
+	}
+	r, err := NewReplayer(g.Setup(), moves)
+	if err != nil {
+		t.Fatalf("NewReplayer(): got %v, want no error", err)
+	}
+	if err := r.Seek(3); err != nil {
+		t.Fatalf("Seek(3): got %v, want no error", err)
+	}
+	if err := r.Next(); err == nil {
+		t.Error("Next() at last position: got no error, want error")
+	}
+	if err := r.Seek(1); err != nil {
+		t.Fatalf("Seek(1): got %v, want no error", err)
+	}
+	if got, want := r.Position(), 1; got != want {
+		t.Errorf("Position(): got %v, want %v", got, want)
+	}
+	if got := r.Game().Board.Cell(Coord{9, 9}); got.IsColored() {
+		t.Errorf("Cell(9,9) at position 1: got %v, want empty", got)
+	}
+	if got, want := r.Game().Board.Cell(Coord{2, 0}), Blue; got != want {
+		t.Errorf("Cell(2,0) at position 1: got %v, want %v", got, want)
+	}
+	if err := r.Seek(0); err != nil {
+		t.Fatalf("Seek(0): got %v, want no error", err)
+	}
+	if err := r.Prev(); err == nil {
+		t.Error("Prev() at initial position: got no error, want error")
+	}
+	if err := r.Seek(4); err == nil {
+		t.Error("Seek(4): got no error, want out of range error")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 0, Loc: Coord{0, 0}})
+	if err := g.Verify(); err != nil {
+		t.Errorf("Verify(): got %v, want no error", err)
+	}
+	g.Board.SetCell(Coord{5, 5}, Yellow)
+	if err := g.Verify(); err == nil || !strings.Contains(err.Error(), "row 5") {
+		t.Errorf("Verify() with tampered board: got %v, want error about row 5", err)
+	}
+}
+
+func TestResignRoundTrip(t *testing.T) {
+	g, err := ClassicVariant().NewGame(DefaultPieces(), []string{"alice", "bob", "carol", "dave"})
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	playRandomly(t, g, 9)
+	// Carol resigns while it's dave's turn.
+	playOrDie(t, g, Move{Player: g.Players[2], PieceIndex: -1, Resign: true})
+	if got, want := g.CurPlayerIndex, 1; got != want {
+		t.Errorf("Player turn after resignation out of turn: got %v, want %v", got, want)
+	}
+	playRandomly(t, g, 20)
+	if err := g.Verify(); err != nil {
+		t.Errorf("Verify(): got %v, want no error", err)
+	}
+	moves := make([]Move, 0, len(g.Moves))
+	for _, m := range g.Moves {
+		moves = append(moves, *m)
+	}
+	replayed, err := Replay(g.Setup(), moves)
+	if err != nil {
+		t.Fatalf("Replay(): got %v, want no error", err)
+	}
+	if got, want := replayed.Players[2].Status, StatusResigned; got != want {
+		t.Errorf("Replayed status of resigned player: got %v, want %v", got, want)
+	}
+	if got, want := replayed.Hash(), g.Hash(); got != want {
+		t.Errorf("Replayed hash: got %x, want %x", got, want)
+	}
+
+	r, err := g.Record()
+	if err != nil {
+		t.Fatalf("Record(): got %v, want no error", err)
+	}
+	var buf bytes.Buffer
+	if err := WriteRecord(&buf, r); err != nil {
+		t.Fatalf("WriteRecord(): got %v, want no error", err)
+	}
+	if !strings.Contains(buf.String(), "R resign") {
+		t.Errorf("Written record: got %q, want the resignation %q", buf.String(), "R resign")
+	}
+	read, err := ReadRecord(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecord(): got %v, want no error", err)
+	}
+	fromRecord, err := read.Replay()
+	if err != nil {
+		t.Fatalf("Replay() of record: got %v, want no error", err)
+	}
+	if got, want := fromRecord.Hash(), g.Hash(); got != want {
+		t.Errorf("Replayed record hash: got %x, want %x", got, want)
+	}
+
+	data, err := g.MarshalMoves(moves)
+	if err != nil {
+		t.Fatalf("MarshalMoves(): got %v, want no error", err)
+	}
+	if got, err := g.UnmarshalMoves(data); err != nil || !reflect.DeepEqual(got, moves) {
+		t.Errorf("UnmarshalMoves(): got %v, %v, want %v", got, err, moves)
+	}
+	j, err := json.Marshal(g.Moves[9])
+	if err != nil {
+		t.Fatalf("Marshal(): got %v, want no error", err)
+	}
+	var m Move
+	if err := json.Unmarshal(j, &m); err != nil || !m.Resign || m.IsPass() {
+		t.Errorf("Unmarshal(%s): got %+v, %v, want resignation", j, m, err)
+	}
+
+	// Taking back the resignation gives the turn back to the player it was with.
+	for len(g.Moves) > 10 {
+		if _, err := g.Undo(); err != nil {
+			t.Fatalf("Undo(): got %v, want no error", err)
+		}
+	}
+	if _, err := g.Undo(); err != nil {
+		t.Fatalf("Undo() of resignation: got %v, want no error", err)
+	}
+	if got, want := g.Players[2].Status, StatusActive; got != want {
+		t.Errorf("Status after undoing resignation: got %v, want %v", got, want)
+	}
+	if got, want := g.CurPlayerIndex, 1; got != want {
+		t.Errorf("Player turn after undoing resignation: got %v, want %v", got, want)
+	}
+}
+
+func TestBindMovePlayers(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 0, Loc: Coord{0, 0}})
+	playOrDie(t, g, Move{Player: g.Players[1], PieceIndex: -1})
+	// As when loaded from datastore, the moves have copies of the players.
+	for _, m := range g.Moves {
+		m.Player = m.Player.clone()
+	}
+	if _, err := g.Undo(); err == nil {
+		t.Error("Undo() with copied move players: got no error, want error")
+	}
+	if err := g.BindMovePlayers(); err != nil {
+		t.Fatalf("BindMovePlayers(): got %v, want no error", err)
+	}
+	for i, m := range g.Moves {
+		if g.playerIndex(m.Player) < 0 {
+			t.Errorf("Move %d player after BindMovePlayers(): got player not in game, want player in game", i)
+		}
+	}
+	if _, err := g.Undo(); err != nil {
+		t.Errorf("Undo() after BindMovePlayers(): got %v, want no error", err)
+	}
+	g.Moves[0].Player = &Player{Color: Red}
+	if err := g.BindMovePlayers(); err == nil {
+		t.Error("BindMovePlayers() with unknown color: got no error, want error")
+	}
+}
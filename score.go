@@ -0,0 +1,152 @@
+package blokus
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// Bonus points for placing all pieces on the board.
+	allPiecesPlacedBonus = 15
+	// Extra bonus points if the last piece placed was the monomino.
+	monominoLastBonus = 5
+)
+
+// Score is the result of one player according to the standard scoring rules.
+type Score struct {
+	Player *Player
+	// RemainingSquares is the total number of squares of the pieces the player has not placed.
+	RemainingSquares int
+	// AllPlaced is whether the player placed all pieces.
+	AllPlaced bool
+	// MonominoLast is whether the player placed all pieces and the last one was the monomino.
+	MonominoLast bool
+	// Total is the score of the player: one point lost per remaining square, plus any bonus.
+	Total int
+}
+
+// Score calculates the score of the player from the pieces placed so far.
+func (g *Game) Score(player *Player) (*Score, error) {
+	if player == nil {
+		return nil, fmt.Errorf("Invalid player")
+	}
+	if len(player.PlacedPieces) != len(g.Pieces) {
+		return nil, fmt.Errorf("Player %v has %d pieces, but the game has %d", player.Name, len(player.PlacedPieces), len(g.Pieces))
+	}
+	s := &Score{
+		Player:    player,
+		AllPlaced: true,
+	}
+	for i, placed := range player.PlacedPieces {
+		if placed {
+			continue
+		}
+		s.AllPlaced = false
+		if g.Pieces[i] != nil {
+			s.RemainingSquares += len(g.Pieces[i].Blocks)
+		}
+	}
+	s.Total = -s.RemainingSquares
+	if s.AllPlaced {
+		s.Total += allPiecesPlacedBonus
+		if m := g.lastPlacement(player); m != nil && len(g.Pieces[m.PieceIndex].Blocks) == 1 {
+			s.MonominoLast = true
+			s.Total += monominoLastBonus
+		}
+	}
+	return s, nil
+}
+
+// Scores calculates the scores of all players, in player order.
+func (g *Game) Scores() ([]*Score, error) {
+	scores := make([]*Score, 0, len(g.Players))
+	for _, p := range g.Players {
+		s, err := g.Score(p)
+		if err != nil {
+			return nil, err
+		}
+		scores = append(scores, s)
+	}
+	return scores, nil
+}
+
+// lastPlacement returns the last move in which the player placed a piece, or nil if there's none.
+func (g *Game) lastPlacement(player *Player) *Move {
+	for i := len(g.Moves) - 1; i >= 0; i-- {
+		if m := g.Moves[i]; m.Player == player && m.placesPiece() {
+			return m
+		}
+	}
+	return nil
+}
+
+// Standing is the position of one participant in the ranking of a game.
+type Standing struct {
+	// Rank starts from 1 for the highest score. Participants with equal scores share the same rank.
+	Rank int
+	// Tied is whether another participant has the same rank.
+	Tied bool
+	// Participant is the name of the person ranked.
+	Participant string
+	// Total is the combined score of all colors played by the participant.
+	Total int
+	// Scores of the colors played by the participant, in player order.
+	Scores []*Score
+}
+
+// Standings ranks the participants by their combined score, from highest to lowest. Shared colors are not ranked.
+// Tied participants keep the order of their first color.
+func (g *Game) Standings() ([]*Standing, error) {
+	scores, err := g.Scores()
+	if err != nil {
+		return nil, err
+	}
+	var standings []*Standing
+	byParticipant := map[string]*Standing{}
+	for _, s := range scores {
+		if s.Player.IsShared() {
+			continue
+		}
+		name := s.Player.Participant()
+		st, ok := byParticipant[name]
+		if !ok {
+			st = &Standing{Participant: name}
+			byParticipant[name] = st
+			standings = append(standings, st)
+		}
+		st.Scores = append(st.Scores, s)
+		st.Total += s.Total
+	}
+	rankStandings(standings)
+	return standings, nil
+}
+
+func rankStandings(standings []*Standing) {
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Total > standings[j].Total
+	})
+	for i, s := range standings {
+		if i > 0 && s.Total == standings[i-1].Total {
+			s.Rank = standings[i-1].Rank
+			s.Tied = true
+			standings[i-1].Tied = true
+		} else {
+			s.Rank = i + 1
+		}
+	}
+}
+
+// Winners returns the names of the participants with the highest score. There's more than one winner if tied.
+func (g *Game) Winners() ([]string, error) {
+	standings, err := g.Standings()
+	if err != nil {
+		return nil, err
+	}
+	var winners []string
+	for _, s := range standings {
+		if s.Rank == 1 {
+			winners = append(winners, s.Participant)
+		}
+	}
+	return winners, nil
+}
@@ -0,0 +1,148 @@
+package blokus
+
+import (
+	"testing"
+)
+
+func newGameWithMonominoAndDomino(t *testing.T) *Game {
+	g, err := NewGame(5, []*Piece{
+		newPieceOrDie(t, []Coord{{0, 0}}),
+		newPieceOrDie(t, []Coord{{0, 0}, {1, 0}}),
+	})
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	if err := g.AddPlayer("foo", Blue, Coord{0, 0}); err != nil {
+		t.Fatalf("AddPlayer(foo): got %v, want no error", err)
+	}
+	if err := g.AddPlayer("bar", Yellow, Coord{4, 4}); err != nil {
+		t.Fatalf("AddPlayer(bar): got %v, want no error", err)
+	}
+	return g
+}
+
+func placePieceOrDie(t *testing.T, g *Game, pieceIndex int, orient Orientation, loc Coord) {
+	if err := g.PlacePiece(g.CurrentPlayer(), pieceIndex, orient, loc); err != nil {
+		t.Fatalf("PlacePiece(%v, %v, %v): got %v, want no error", pieceIndex, orient, loc, err)
+	}
+	if err := g.AdvanceTurn(); err != nil {
+		t.Fatalf("AdvanceTurn(): got %v, want no error", err)
+	}
+}
+
+func TestScoreNoPiecesPlaced(t *testing.T) {
+	g := newGameWithMonominoAndDomino(t)
+	s, err := g.Score(g.Players[0])
+	if err != nil {
+		t.Fatalf("Score(): got %v, want no error", err)
+	}
+	if got, want := s.RemainingSquares, 3; got != want {
+		t.Errorf("Score() remaining squares: got %v, want %v", got, want)
+	}
+	if got, want := s.Total, -3; got != want {
+		t.Errorf("Score() total: got %v, want %v", got, want)
+	}
+	if s.AllPlaced || s.MonominoLast {
+		t.Errorf("Score() bonuses: got AllPlaced=%v MonominoLast=%v, want false", s.AllPlaced, s.MonominoLast)
+	}
+}
+
+func TestScoreNilPlayer(t *testing.T) {
+	g := newGameWithMonominoAndDomino(t)
+	if _, err := g.Score(nil); err == nil {
+		t.Error("Score(nil): got no error, want error")
+	}
+}
+
+func TestScoreAllPlacedMonominoLast(t *testing.T) {
+	g := newGameWithMonominoAndDomino(t)
+	placePieceOrDie(t, g, 1, Orientation{Rot0, false}, Coord{0, 0})
+	placePieceOrDie(t, g, 0, Orientation{Rot0, false}, Coord{4, 4})
+	placePieceOrDie(t, g, 0, Orientation{Rot0, false}, Coord{2, 1})
+	placePieceOrDie(t, g, 1, Orientation{Rot0, false}, Coord{2, 3})
+
+	blue, err := g.Score(g.Players[0])
+	if err != nil {
+		t.Fatalf("Score(blue): got %v, want no error", err)
+	}
+	if got, want := blue.Total, allPiecesPlacedBonus+monominoLastBonus; got != want {
+		t.Errorf("Score(blue) total: got %v, want %v", got, want)
+	}
+	if !blue.AllPlaced || !blue.MonominoLast {
+		t.Errorf("Score(blue) bonuses: got AllPlaced=%v MonominoLast=%v, want true", blue.AllPlaced, blue.MonominoLast)
+	}
+
+	yellow, err := g.Score(g.Players[1])
+	if err != nil {
+		t.Fatalf("Score(yellow): got %v, want no error", err)
+	}
+	if got, want := yellow.Total, allPiecesPlacedBonus; got != want {
+		t.Errorf("Score(yellow) total: got %v, want %v", got, want)
+	}
+	if !yellow.AllPlaced || yellow.MonominoLast {
+		t.Errorf("Score(yellow) bonuses: got AllPlaced=%v MonominoLast=%v, want true and false", yellow.AllPlaced, yellow.MonominoLast)
+	}
+}
+
+func TestStandings(t *testing.T) {
+	g := newGameWithMonominoAndDomino(t)
+	placePieceOrDie(t, g, 1, Orientation{Rot0, false}, Coord{0, 0})
+
+	standings, err := g.Standings()
+	if err != nil {
+		t.Fatalf("Standings(): got %v, want no error", err)
+	}
+	if got, want := len(standings), 2; got != want {
+		t.Fatalf("Standings() len: got %v, want %v", got, want)
+	}
+	if got, want := standings[0].Participant, g.Players[0].Name; got != want {
+		t.Errorf("Standings()[0] participant: got %v, want %v", got, want)
+	}
+	if got, want := standings[0].Total, -1; got != want {
+		t.Errorf("Standings()[0] total: got %v, want %v", got, want)
+	}
+	if got, want := standings[0].Rank, 1; got != want {
+		t.Errorf("Standings()[0] rank: got %v, want %v", got, want)
+	}
+	if got, want := standings[1].Rank, 2; got != want {
+		t.Errorf("Standings()[1] rank: got %v, want %v", got, want)
+	}
+	if standings[0].Tied || standings[1].Tied {
+		t.Errorf("Standings() tied: got %v and %v, want false", standings[0].Tied, standings[1].Tied)
+	}
+}
+
+func TestStandingsTied(t *testing.T) {
+	g := newGameWithMonominoAndDomino(t)
+	if err := g.AddPlayer("baz", Red, Coord{0, 4}); err != nil {
+		t.Fatalf("AddPlayer(baz): got %v, want no error", err)
+	}
+	placePieceOrDie(t, g, 0, Orientation{Rot0, false}, Coord{0, 0})
+
+	standings, err := g.Standings()
+	if err != nil {
+		t.Fatalf("Standings(): got %v, want no error", err)
+	}
+	wantRanks := []int{1, 2, 2}
+	wantTied := []bool{false, true, true}
+	for i, s := range standings {
+		if got, want := s.Rank, wantRanks[i]; got != want {
+			t.Errorf("Standings()[%d] rank: got %v, want %v", i, got, want)
+		}
+		if got, want := s.Tied, wantTied[i]; got != want {
+			t.Errorf("Standings()[%d] tied: got %v, want %v", i, got, want)
+		}
+	}
+	// Tied players keep their player order.
+	if got, want := standings[1].Participant, g.Players[1].Name; got != want {
+		t.Errorf("Standings()[1] participant: got %v, want %v", got, want)
+	}
+
+	winners, err := g.Winners()
+	if err != nil {
+		t.Fatalf("Winners(): got %v, want no error", err)
+	}
+	if len(winners) != 1 || winners[0] != g.Players[0].Name {
+		t.Errorf("Winners(): got %v, want only %v", winners, g.Players[0].Name)
+	}
+}
@@ -0,0 +1,91 @@
+package blokus
+
+import (
+	"sort"
+)
+
+func lessCoord(a, b Coord) bool {
+	if a.X != b.X {
+		return a.X < b.X
+	}
+	return a.Y < b.Y
+}
+
+// normalizeCoords returns the coordinates sorted by X then Y, and moved so that the first one is at the origin.
+// Coordinates with the same shape and differing only by position normalize to the same slice.
+func normalizeCoords(cs []Coord) []Coord {
+	out := append([]Coord(nil), cs...)
+	sort.Slice(out, func(i, j int) bool {
+		return lessCoord(out[i], out[j])
+	})
+	if len(out) == 0 {
+		return out
+	}
+	origin := out[0]
+	for i := range out {
+		out[i] = Coord{out[i].X - origin.X, out[i].Y - origin.Y}
+	}
+	return out
+}
+
+func equalCoords(a, b []Coord) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func lessCoords(a, b []Coord) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return lessCoord(a[i], b[i])
+		}
+	}
+	return len(a) < len(b)
+}
+
+// Normalized returns a copy of the piece with its blocks sorted, and moved so that the first block is at (0,0).
+func (p *Piece) Normalized() *Piece {
+	n := &Piece{Name: p.Name, Blocks: normalizeCoords(p.Blocks)}
+	n.warmCaches()
+	return n
+}
+
+// Canonical returns the normalized blocks of the piece in the orientation whose blocks sort first.
+// Pieces with the same shape, regardless of position, rotation or flipping, have equal canonical blocks.
+func (p *Piece) Canonical() []Coord {
+	return canonicalCoords(p.Blocks)
+}
+
+func canonicalCoords(cs []Coord) []Coord {
+	var canonical []Coord
+	for _, o := range AllOrientations() {
+		blocks := normalizeCoords(o.TransformCoords(cs))
+		if canonical == nil || lessCoords(blocks, canonical) {
+			canonical = blocks
+		}
+	}
+	return canonical
+}
+
+// SameShape returns whether the other piece can be rotated, flipped and moved to match this piece.
+func (p *Piece) SameShape(other *Piece) bool {
+	return equalCoords(p.Canonical(), other.Canonical())
+}
+
+// UniqueOrientations returns the orientations of the piece that give distinct shapes,
+// e.g. only one for the monomino and eight for an asymmetric piece.
+// For orientations with the same shape, the first in AllOrientations is returned.
+func (p *Piece) UniqueOrientations() []Orientation {
+	shapes := p.orientedShapes()
+	os := make([]Orientation, 0, len(shapes))
+	for _, s := range shapes {
+		os = append(os, s.orient)
+	}
+	return os
+}
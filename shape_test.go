@@ -0,0 +1,76 @@
+package blokus
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNormalized(t *testing.T) {
+	p := newPieceOrDie(t, []Coord{{2, 1}, {1, 1}, {1, 2}, {0, 2}})
+	if got, want := p.Normalized().Blocks, []Coord{{0, 0}, {1, -1}, {1, 0}, {2, -1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Normalized(): got %v, want %v", got, want)
+	}
+}
+
+func TestCanonicalSameShape(t *testing.T) {
+	// L tetromino in different orientations and positions.
+	a := newPieceOrDie(t, []Coord{{0, 0}, {1, 0}, {2, 0}, {2, 1}})
+	b := newPieceOrDie(t, []Coord{{5, 5}, {5, 6}, {5, 7}, {6, 5}})
+	if !reflect.DeepEqual(a.Canonical(), b.Canonical()) {
+		t.Errorf("Canonical(): got %v and %v, want equal", a.Canonical(), b.Canonical())
+	}
+	if !a.SameShape(b) {
+		t.Error("SameShape(): got false, want true")
+	}
+	// T tetromino has a different shape.
+	c := newPieceOrDie(t, []Coord{{0, 0}, {1, 0}, {2, 0}, {1, 1}})
+	if a.SameShape(c) {
+		t.Error("SameShape() with a different piece: got true, want false")
+	}
+}
+
+func TestUniqueOrientations(t *testing.T) {
+	testCases := []struct {
+		name   string
+		blocks []Coord
+		want   int
+	}{
+		{"monomino", []Coord{{0, 0}}, 1},
+		{"domino", []Coord{{0, 0}, {1, 0}}, 2},
+		{"square", []Coord{{0, 0}, {1, 0}, {0, 1}, {1, 1}}, 1},
+		{"X", []Coord{{0, 0}, {1, 0}, {2, 0}, {1, 1}, {1, -1}}, 1},
+		{"Z tetromino", []Coord{{0, 0}, {1, 0}, {1, 1}, {2, 1}}, 4},
+		{"L tetromino", []Coord{{0, 0}, {1, 0}, {2, 0}, {2, 1}}, 8},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newPieceOrDie(t, tc.blocks)
+			os := p.UniqueOrientations()
+			if got := len(os); got != tc.want {
+				t.Errorf("UniqueOrientations(): got %v, want %v", os, tc.want)
+			}
+			// Every orientation must give a shape none of the others give.
+			seen := [][]Coord{}
+			for _, o := range os {
+				n := normalizeCoords(o.TransformCoords(p.Blocks))
+				for _, s := range seen {
+					if reflect.DeepEqual(s, n) {
+						t.Errorf("UniqueOrientations(): orientation %v repeats shape %v", o, n)
+					}
+				}
+				seen = append(seen, n)
+			}
+		})
+	}
+}
+
+func TestDefaultPiecesUniqueOrientationCount(t *testing.T) {
+	total := 0
+	for _, p := range DefaultPieces() {
+		total += len(p.UniqueOrientations())
+	}
+	// The standard set has 91 distinct oriented shapes.
+	if got, want := total, 91; got != want {
+		t.Errorf("Total unique orientations of default pieces: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,67 @@
+package blokus
+
+import (
+	"fmt"
+)
+
+// AddSharedPlayer adds a color that nobody owns, but that the given people play in rotation,
+// e.g. the fourth color in a three player game. The player is named after the color.
+func (g *Game) AddSharedPlayer(color Color, startPos Coord, sharedBy []string) error {
+	if len(sharedBy) == 0 {
+		return fmt.Errorf("Shared color must be shared by at least one person")
+	}
+	for _, name := range sharedBy {
+		if err := checkName(name); err != nil {
+			return fmt.Errorf("Invalid name of who shares the color: %v", err)
+		}
+	}
+	if color == colorEmpty {
+		var err error
+		color, err = g.GetNextFreeColor()
+		if err != nil {
+			return err
+		}
+	}
+	p, err := g.addPlayer(fmt.Sprintf("%s%v", sharedNamePrefix, color), color, startPos)
+	if err != nil {
+		return err
+	}
+	p.SharedBy = append([]string(nil), sharedBy...)
+	return nil
+}
+
+// Controller returns the name of who plays the next turn of the player.
+// For a shared color, this rotates through the people sharing it, one turn of the color each.
+func (g *Game) Controller(player *Player) string {
+	if !player.IsShared() {
+		return player.Participant()
+	}
+	turns := 0
+	for _, m := range g.Moves {
+		if m.Player == player {
+			turns++
+		}
+	}
+	return player.SharedBy[turns%len(player.SharedBy)]
+}
+
+// CurrentController returns the name of who plays the current turn.
+func (g *Game) CurrentController() string {
+	return g.Controller(g.CurrentPlayer())
+}
+
+// onlySharedActive returns whether shared colors are the only active players left,
+// while the game has players that are not shared.
+func (g *Game) onlySharedActive() bool {
+	hasOwnPlayer := false
+	for _, p := range g.Players {
+		if p.IsShared() {
+			continue
+		}
+		if p.Status.IsActive() {
+			return false
+		}
+		hasOwnPlayer = true
+	}
+	return hasOwnPlayer
+}
@@ -0,0 +1,101 @@
+package blokus
+
+import (
+	"reflect"
+	"testing"
+)
+
+func newThreePlayerGameOrDie(t *testing.T) *Game {
+	g, err := ThreePlayerVariant().NewGame(DefaultPieces(), []string{"foo", "bar", "baz"})
+	if err != nil {
+		t.Fatalf("ThreePlayerVariant().NewGame(): got %v, want no error", err)
+	}
+	return g
+}
+
+func TestThreePlayerVariantNewGame(t *testing.T) {
+	g := newThreePlayerGameOrDie(t)
+	if got, want := len(g.Players), 4; got != want {
+		t.Fatalf("Num players: got %v, want %v", got, want)
+	}
+	shared := g.Players[3]
+	if got, want := shared.Name, "shared green"; got != want {
+		t.Errorf("Shared player name: got %v, want %v", got, want)
+	}
+	if got, want := shared.SharedBy, []string{"foo", "bar", "baz"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Shared player SharedBy: got %v, want %v", got, want)
+	}
+	for _, p := range g.Players[:3] {
+		if p.IsShared() {
+			t.Errorf("Player %v IsShared(): got true, want false", p.Name)
+		}
+	}
+}
+
+func TestAddSharedPlayerNoOwners(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddSharedPlayer(Green, Coord{0, 0}, nil); err == nil {
+		t.Error("AddSharedPlayer() with nobody sharing: got no error, want error")
+	}
+}
+
+func TestControllerRotates(t *testing.T) {
+	g := newThreePlayerGameOrDie(t)
+	for _, want := range []string{"foo", "bar", "baz", "foo"} {
+		// Each round, the first three players pass and the shared color is played by the next person.
+		for i := 0; i < 3; i++ {
+			playOrDie(t, g, Move{Player: g.CurrentPlayer(), PieceIndex: -1})
+		}
+		if got := g.CurrentController(); got != want {
+			t.Errorf("CurrentController() for shared color: got %v, want %v", got, want)
+		}
+		if got := g.CurrentPlayer(); got != g.Players[3] {
+			t.Fatalf("CurrentPlayer(): got %v, want shared player", got.Name)
+		}
+		playOrDie(t, g, g.LegalMoves(g.CurrentPlayer())[0])
+	}
+	if got, want := g.Controller(g.Players[0]), "foo"; got != want {
+		t.Errorf("Controller() for own color: got %v, want %v", got, want)
+	}
+}
+
+func TestSharedPlayerNotRanked(t *testing.T) {
+	g := newThreePlayerGameOrDie(t)
+	standings, err := g.Standings()
+	if err != nil {
+		t.Fatalf("Standings(): got %v, want no error", err)
+	}
+	if got, want := len(standings), 3; got != want {
+		t.Fatalf("Standings() len: got %v, want %v", got, want)
+	}
+	for _, s := range standings {
+		for _, sc := range s.Scores {
+			if sc.Player.IsShared() {
+				t.Errorf("Standings(): got shared player %v, want only own players", sc.Player.Name)
+			}
+		}
+	}
+}
+
+func TestGameEndsWhenOnlySharedActive(t *testing.T) {
+	g := newThreePlayerGameOrDie(t)
+	for _, p := range g.Players[:3] {
+		if err := g.Resign(p); err != nil {
+			t.Fatalf("Resign(%v): got %v, want no error", p.Name, err)
+		}
+	}
+	if got := g.IsGameEnd(); !got {
+		t.Errorf("IsGameEnd() with only shared color active: got %v, want true", got)
+	}
+}
+
+func TestSetupSharedPlayer(t *testing.T) {
+	g := newThreePlayerGameOrDie(t)
+	got, err := g.Setup().NewGame()
+	if err != nil {
+		t.Fatalf("Setup().NewGame(): got %v, want no error", err)
+	}
+	if !reflect.DeepEqual(got.Players, g.Players) {
+		t.Errorf("Setup().NewGame() players: got %v, want %v", got.Players, g.Players)
+	}
+}
@@ -0,0 +1,105 @@
+package blokus
+
+import (
+	"fmt"
+)
+
+// UndoPolicy returns an error if the move may not be taken back in the game.
+type UndoPolicy func(g *Game, m *Move) error
+
+// ForbidUndo is an UndoPolicy that doesn't allow taking back any move, e.g. for competitive games.
+func ForbidUndo(g *Game, m *Move) error {
+	return fmt.Errorf("Undo is not allowed in this game")
+}
+
+// Undo takes back the last move, removing its piece from the board and giving the turn back to the player who made it.
+// The move can be played again with Redo until another move is made.
+func (g *Game) Undo() (*Move, error) {
+	if len(g.Moves) == 0 {
+		return nil, fmt.Errorf("No moves to undo")
+	}
+	m := g.Moves[len(g.Moves)-1]
+	if g.UndoPolicy != nil {
+		if err := g.UndoPolicy(g, m); err != nil {
+			return nil, err
+		}
+	}
+	playerIndex := g.playerIndex(m.Player)
+	if playerIndex < 0 {
+		return nil, fmt.Errorf("Last move was made by a player not in the game")
+	}
+
+	before, ok := g.turnStates[m]
+	if !ok {
+		var err error
+		if before, err = g.stateBeforeLastMove(); err != nil {
+			return nil, err
+		}
+	}
+	if m.placesPiece() {
+		if m.PieceIndex >= len(g.Pieces) || m.PieceIndex >= len(m.Player.PlacedPieces) || g.Pieces[m.PieceIndex] == nil {
+			return nil, fmt.Errorf("Last move has invalid piece index: %d", m.PieceIndex)
+		}
+		for _, b := range m.Orient.TransformCoords(g.Pieces[m.PieceIndex].Blocks) {
+			g.Board.SetCell(Coord{m.Loc.X + b.X, m.Loc.Y + b.Y}, colorEmpty)
+		}
+		m.Player.PlacedPieces[m.PieceIndex] = false
+	}
+	for i, p := range g.Players {
+		p.Status = before.statuses[i]
+	}
+	g.CurPlayerIndex = before.cur
+	g.Moves = g.Moves[:len(g.Moves)-1]
+	g.Redos = append(g.Redos, m)
+	delete(g.turnStates, m)
+	return m, nil
+}
+
+// Redo plays the last undone move again.
+func (g *Game) Redo() (TurnResult, error) {
+	if len(g.Redos) == 0 {
+		return TurnResult{}, fmt.Errorf("No moves to redo")
+	}
+	m := g.Redos[len(g.Redos)-1]
+	redos := g.Redos[:len(g.Redos)-1]
+	r, err := g.Play(*m)
+	if err != nil {
+		return TurnResult{}, err
+	}
+	// Playing the move cleared the remaining redos.
+	g.Redos = redos
+	return r, nil
+}
+
+// turnState returns the statuses of the players and whose turn it is.
+func (g *Game) turnState() turnState {
+	s := turnState{statuses: make([]PlayerStatus, len(g.Players)), cur: g.CurPlayerIndex}
+	for i, p := range g.Players {
+		s.statuses[i] = p.Status
+	}
+	return s
+}
+
+// stateBeforeLastMove finds the state before the last move by replaying the moves before it,
+// for moves that Play didn't record a state for.
+func (g *Game) stateBeforeLastMove() (turnState, error) {
+	moves := make([]Move, 0, len(g.Moves)-1)
+	for _, m := range g.Moves[:len(g.Moves)-1] {
+		moves = append(moves, *m)
+	}
+	before, err := Replay(g.Setup(), moves)
+	if err != nil {
+		return turnState{}, fmt.Errorf("Could not find the position before the last move: %v", err)
+	}
+	return before.turnState(), nil
+}
+
+// playerIndex returns the index of the player in the game, or -1 if not found.
+func (g *Game) playerIndex(player *Player) int {
+	for i, p := range g.Players {
+		if p == player {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,165 @@
+package blokus
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func playOrDie(t *testing.T, g *Game, m Move) TurnResult {
+	r, err := g.Play(m)
+	if err != nil {
+		t.Fatalf("Play(%v): got %v, want no error", m, err)
+	}
+	return r
+}
+
+func TestUndo(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	emptyGrid := make([]Color, 100)
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 1, Orient: Orientation{Rot90, true}, Loc: Coord{0, 0}})
+
+	m, err := g.Undo()
+	if err != nil {
+		t.Fatalf("Undo(): got %v, want no error", err)
+	}
+	if got, want := m.PieceIndex, 1; got != want {
+		t.Errorf("Undo() move piece index: got %v, want %v", got, want)
+	}
+	if got := len(g.Moves); got != 0 {
+		t.Errorf("Num moves after Undo(): got %v, want 0", got)
+	}
+	if got, want := len(g.Redos), 1; got != want {
+		t.Errorf("Num redos after Undo(): got %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(g.Board.Grid, emptyGrid) {
+		t.Errorf("Board after Undo(): got %v, want empty", g.Board.Grid)
+	}
+	if g.Players[0].PlacedPieces[1] {
+		t.Error("Player placed piece after Undo(): got true, want false")
+	}
+	if got, want := g.CurPlayerIndex, 0; got != want {
+		t.Errorf("Player turn after Undo(): got %v, want %v", got, want)
+	}
+}
+
+func TestUndoPass(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: -1})
+	if _, err := g.Undo(); err != nil {
+		t.Fatalf("Undo(): got %v, want no error", err)
+	}
+	if got, want := g.CurPlayerIndex, 0; got != want {
+		t.Errorf("Player turn after Undo(): got %v, want %v", got, want)
+	}
+}
+
+func TestUndoNoMoves(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	if _, err := g.Undo(); err == nil || !strings.Contains(err.Error(), "No moves") {
+		t.Errorf("Undo() with no moves: got %v, want no moves error", err)
+	}
+}
+
+func TestUndoPolicy(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	g.UndoPolicy = ForbidUndo
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: -1})
+	if _, err := g.Undo(); err == nil || !strings.Contains(err.Error(), "not allowed") {
+		t.Errorf("Undo() with ForbidUndo policy: got %v, want not allowed error", err)
+	}
+	if got, want := len(g.Moves), 1; got != want {
+		t.Errorf("Num moves after forbidden Undo(): got %v, want %v", got, want)
+	}
+}
+
+func TestUndoRestoresStatus(t *testing.T) {
+	g := newGameOrDie(t)
+	if err := g.AddPlayer("foo", Blue, Coord{0, 0}); err != nil {
+		t.Fatalf("AddPlayer(foo): got %v, want no error", err)
+	}
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 0, Loc: Coord{0, 0}})
+	if _, err := g.Undo(); err != nil {
+		t.Fatalf("Undo(): got %v, want no error", err)
+	}
+	if got, want := g.Players[0].Status, StatusActive; got != want {
+		t.Errorf("Player status after Undo(): got %v, want %v", got, want)
+	}
+	if got := g.IsGameEnd(); got {
+		t.Errorf("IsGameEnd() after Undo(): got %v, want false", got)
+	}
+}
+
+func TestUndoRestoresPosition(t *testing.T) {
+	g, err := ClassicVariant().NewGame(DefaultPieces(), []string{"alice", "bob", "carol", "dave"})
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	var hashes []uint64
+	for !g.IsGameEnd() {
+		hashes = append(hashes, g.Hash())
+		playRandomly(t, g, 1)
+	}
+	outOfMoves := false
+	for _, p := range g.Players {
+		outOfMoves = outOfMoves || p.Status == StatusOutOfMoves
+	}
+	if !outOfMoves {
+		t.Fatal("Statuses at the end: got no player out of moves, want one to test")
+	}
+	// Moves recorded by Play are undone from their saved state, and the others by replaying.
+	half := len(hashes) / 2
+	for _, m := range g.Moves[:half] {
+		delete(g.turnStates, m)
+	}
+	for i := len(hashes) - 1; i >= 0; i-- {
+		if _, err := g.Undo(); err != nil {
+			t.Fatalf("Undo() of move %d: got %v, want no error", i, err)
+		}
+		if got, want := g.Hash(), hashes[i]; got != want {
+			t.Fatalf("Hash() after undoing move %d: got %x, want %x", i, got, want)
+		}
+	}
+}
+
+func TestRedo(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 0, Loc: Coord{0, 0}})
+	playOrDie(t, g, Move{Player: g.Players[1], PieceIndex: 0, Loc: Coord{7, 9}})
+	wantGrid := append([]Color(nil), g.Board.Grid...)
+
+	for i := 0; i < 2; i++ {
+		if _, err := g.Undo(); err != nil {
+			t.Fatalf("Undo() #%d: got %v, want no error", i, err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := g.Redo(); err != nil {
+			t.Fatalf("Redo() #%d: got %v, want no error", i, err)
+		}
+	}
+	if _, err := g.Redo(); err == nil || !strings.Contains(err.Error(), "No moves") {
+		t.Errorf("Redo() with no undone moves: got %v, want no moves error", err)
+	}
+	if got, want := len(g.Moves), 2; got != want {
+		t.Errorf("Num moves after Redo(): got %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(g.Board.Grid, wantGrid) {
+		t.Errorf("Board after Redo(): got %v, want %v", g.Board.Grid, wantGrid)
+	}
+	if got, want := g.CurPlayerIndex, 0; got != want {
+		t.Errorf("Player turn after Redo(): got %v, want %v", got, want)
+	}
+}
+
+func TestNewMoveClearsRedos(t *testing.T) {
+	g := newGameWithTwoPlayersAndTwoPieces(t, 10)
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 0, Loc: Coord{0, 0}})
+	if _, err := g.Undo(); err != nil {
+		t.Fatalf("Undo(): got %v, want no error", err)
+	}
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: -1})
+	if got := len(g.Redos); got != 0 {
+		t.Errorf("Num redos after new move: got %v, want 0", got)
+	}
+}
@@ -0,0 +1,67 @@
+package blokus
+
+import (
+	"fmt"
+)
+
+// Validate checks that the piece follows the rules for blocks: the first block is at (0,0),
+// no two blocks are on the same square, and all blocks are connected through their edges.
+func (p *Piece) Validate() error {
+	if len(p.Blocks) == 0 {
+		return fmt.Errorf("Piece has no blocks")
+	}
+	if p.Blocks[0] != (Coord{0, 0}) {
+		return fmt.Errorf("First block must be at (0,0), got %v", p.Blocks[0])
+	}
+	blocks := map[Coord]bool{}
+	for _, b := range p.Blocks {
+		if blocks[b] {
+			return fmt.Errorf("Duplicate block at %v", b)
+		}
+		blocks[b] = true
+	}
+	// Flood fill from the first block through shared edges.
+	visited := map[Coord]bool{p.Blocks[0]: true}
+	queue := []Coord{p.Blocks[0]}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		for _, n := range neighbors {
+			n = Coord{c.X + n.X, c.Y + n.Y}
+			if blocks[n] && !visited[n] {
+				visited[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	for _, b := range p.Blocks {
+		if !visited[b] {
+			return fmt.Errorf("Block at %v is not connected to the other blocks by an edge", b)
+		}
+	}
+	return nil
+}
+
+// ValidatePieces checks that every piece is valid, and that no two pieces have the same shape,
+// including when rotated or flipped.
+func ValidatePieces(pieces []*Piece) error {
+	if len(pieces) == 0 {
+		return fmt.Errorf("Piece set has no pieces")
+	}
+	for i, p := range pieces {
+		if p == nil {
+			return fmt.Errorf("Piece %d is nil", i)
+		}
+		if err := p.Validate(); err != nil {
+			return fmt.Errorf("Invalid piece %d: %v", i, err)
+		}
+	}
+	for i, p := range pieces {
+		for j := 0; j < i; j++ {
+			if p.SameShape(pieces[j]) {
+				return fmt.Errorf("Pieces %d and %d have the same shape", j, i)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package blokus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPieceValidate(t *testing.T) {
+	testCases := []struct {
+		desc    string
+		blocks  []Coord
+		wantErr string
+	}{
+		{"monomino", []Coord{{0, 0}}, ""},
+		{"blocks going up and left", []Coord{{0, 0}, {1, 0}, {1, -1}, {0, -1}}, ""},
+		{"no blocks", nil, "no blocks"},
+		{"first block not at origin", []Coord{{3, 4}}, "First block"},
+		{"duplicate block", []Coord{{0, 0}, {1, 0}, {0, 0}}, "Duplicate"},
+		{"diagonal only", []Coord{{0, 0}, {1, 1}}, "not connected"},
+		{"gap", []Coord{{0, 0}, {1, 0}, {3, 0}}, "not connected"},
+	}
+	for _, tc := range testCases {
+		err := (&Piece{Blocks: tc.blocks}).Validate()
+		if tc.wantErr == "" {
+			if err != nil {
+				t.Errorf("Validate() with %v: got error %v, want no error", tc.desc, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("Validate() with %v: got error %v, want error containing %q", tc.desc, err, tc.wantErr)
+		}
+	}
+}
+
+func TestValidatePieces(t *testing.T) {
+	if err := ValidatePieces(DefaultPieces()); err != nil {
+		t.Errorf("ValidatePieces(DefaultPieces()): got error %v, want no error", err)
+	}
+
+	testCases := []struct {
+		desc    string
+		pieces  []*Piece
+		wantErr string
+	}{
+		{"no pieces", nil, "no pieces"},
+		{"nil piece", []*Piece{newPieceOrDie(t, []Coord{{0, 0}}), nil}, "Piece 1 is nil"},
+		{"invalid piece", []*Piece{newPieceOrDie(t, []Coord{{0, 0}, {2, 0}})}, "Invalid piece 0"},
+		{
+			"same piece rotated",
+			[]*Piece{
+				newPieceOrDie(t, []Coord{{0, 0}, {1, 0}, {1, 1}}),
+				newPieceOrDie(t, []Coord{{0, 0}}),
+				newPieceOrDie(t, []Coord{{0, 0}, {0, 1}, {-1, 1}}),
+			},
+			"Pieces 0 and 2",
+		},
+	}
+	for _, tc := range testCases {
+		err := ValidatePieces(tc.pieces)
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("ValidatePieces() with %v: got error %v, want error containing %q", tc.desc, err, tc.wantErr)
+		}
+	}
+}
+
+func TestNewGameRejectsInvalidPieces(t *testing.T) {
+	if _, err := NewGame(DefaultBoardSize, []*Piece{newPieceOrDie(t, []Coord{{3, 4}})}); err == nil {
+		t.Error("NewGame() with piece not at origin: got no error, want error")
+	}
+	ps := []*Piece{newPieceOrDie(t, []Coord{{0, 0}}), newPieceOrDie(t, []Coord{{0, 0}})}
+	if _, err := NewGame(DefaultBoardSize, ps); err == nil {
+		t.Error("NewGame() with duplicate pieces: got no error, want error")
+	}
+}
@@ -0,0 +1,151 @@
+package blokus
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Size of the board for the Duo variant.
+	DuoBoardSize = 14
+)
+
+// Seat is a color played in a variant, along with where it starts.
+type Seat struct {
+	Color    Color
+	StartPos Coord
+	// Owner is the index of the person playing this seat, for variants where a person plays several colors.
+	Owner int
+	// Shared seats have no owner, but are played in rotation by all players.
+	Shared bool
+}
+
+// Variant is a set of rules that fixes how a game is set up.
+type Variant struct {
+	// Name identifies the variant, e.g. "duo".
+	Name string
+	// BoardSize is the length of one edge of the square board.
+	BoardSize int
+	// Seats are the colors in turn order. There's one player per seat, except for shared seats.
+	Seats []Seat
+	// FirstSeat is the index of the seat that moves first.
+	FirstSeat int
+	// ForbidVoluntaryPass is copied to the game.
+	ForbidVoluntaryPass bool
+}
+
+// ClassicVariant returns the rules of the standard four player game, with each color starting from a corner.
+func ClassicVariant() *Variant {
+	last := DefaultBoardSize - 1
+	return &Variant{
+		Name:      "classic",
+		BoardSize: DefaultBoardSize,
+		Seats: []Seat{
+			{Color: Blue, StartPos: Coord{0, 0}, Owner: 0},
+			{Color: Yellow, StartPos: Coord{0, last}, Owner: 1},
+			{Color: Red, StartPos: Coord{last, last}, Owner: 2},
+			{Color: Green, StartPos: Coord{last, 0}, Owner: 3},
+		},
+	}
+}
+
+// ThreePlayerVariant returns the rules of the classic game for three players,
+// where the fourth color is shared by all players in rotation and not scored.
+func ThreePlayerVariant() *Variant {
+	v := ClassicVariant()
+	v.Name = "three"
+	v.Seats[len(v.Seats)-1].Shared = true
+	return v
+}
+
+// TwoPlayerVariant returns the rules of the classic game for two players, where each player plays two colors,
+// blue and red against yellow and green, and is ranked by the combined score.
+func TwoPlayerVariant() *Variant {
+	v := ClassicVariant()
+	v.Name = "two"
+	for i := range v.Seats {
+		v.Seats[i].Owner = i % 2
+	}
+	return v
+}
+
+// DuoVariant returns the rules of Blokus Duo, a two player game on a smaller board
+// where the colors start from squares inside the board instead of corners. Purple moves first.
+func DuoVariant() *Variant {
+	return &Variant{
+		Name:      "duo",
+		BoardSize: DuoBoardSize,
+		Seats: []Seat{
+			{Color: Purple, StartPos: Coord{4, 4}},
+			{Color: Orange, StartPos: Coord{9, 9}, Owner: 1},
+		},
+	}
+}
+
+// LookupVariant returns the built-in variant with the name.
+func LookupVariant(name string) (*Variant, error) {
+	switch strings.ToLower(name) {
+	case "classic":
+		return ClassicVariant(), nil
+	case "two":
+		return TwoPlayerVariant(), nil
+	case "three":
+		return ThreePlayerVariant(), nil
+	case "duo":
+		return DuoVariant(), nil
+	}
+	return nil, fmt.Errorf("Unknown variant: %v", name)
+}
+
+// NumPlayers returns the number of people needed for the variant.
+func (v *Variant) NumPlayers() int {
+	n := 0
+	for _, s := range v.Seats {
+		if !s.Shared && s.Owner >= n {
+			n = s.Owner + 1
+		}
+	}
+	return n
+}
+
+// NewGame creates a game with the rules of the variant.
+// Names are the people playing, indexed by the owners of the seats.
+func (v *Variant) NewGame(pieces []*Piece, names []string) (*Game, error) {
+	if len(names) != v.NumPlayers() {
+		return nil, fmt.Errorf("Variant %v needs %d players, got %d", v.Name, v.NumPlayers(), len(names))
+	}
+	if v.FirstSeat < 0 || v.FirstSeat >= len(v.Seats) {
+		return nil, fmt.Errorf("First seat index out of range: %d", v.FirstSeat)
+	}
+	g, err := NewGame(v.BoardSize, pieces)
+	if err != nil {
+		return nil, err
+	}
+	numSeats := make([]int, len(names))
+	for i, s := range v.Seats {
+		if !s.Shared {
+			if s.Owner < 0 || s.Owner >= len(names) {
+				return nil, fmt.Errorf("Seat %d has invalid owner: %d", i, s.Owner)
+			}
+			numSeats[s.Owner]++
+		}
+	}
+	for i, s := range v.Seats {
+		if !s.Color.IsColored() {
+			return nil, fmt.Errorf("Seat %d has invalid color: %v", i, s.Color)
+		}
+		if s.Shared {
+			err = g.AddSharedPlayer(s.Color, s.StartPos, names)
+		} else {
+			err = g.addSeat(names[s.Owner], s.Color, s.StartPos, numSeats[s.Owner] > 1)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	g.Variant = v.Name
+	g.FirstPlayerIndex = v.FirstSeat
+	g.CurPlayerIndex = v.FirstSeat
+	g.ForbidVoluntaryPass = v.ForbidVoluntaryPass
+	return g, nil
+}
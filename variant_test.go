@@ -0,0 +1,81 @@
+package blokus
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDuoVariantNewGame(t *testing.T) {
+	g, err := DuoVariant().NewGame(DefaultPieces(), []string{"foo", "bar"})
+	if err != nil {
+		t.Fatalf("DuoVariant().NewGame(): got %v, want no error", err)
+	}
+	if got, want := g.Board.Height, DuoBoardSize; got != want {
+		t.Errorf("Board height: got %v, want %v", got, want)
+	}
+	if got, want := g.Variant, "duo"; got != want {
+		t.Errorf("Game variant: got %v, want %v", got, want)
+	}
+	wantPlayers := []struct {
+		name     string
+		color    Color
+		startPos Coord
+	}{
+		{"foo", Purple, Coord{4, 4}},
+		{"bar", Orange, Coord{9, 9}},
+	}
+	if got, want := len(g.Players), len(wantPlayers); got != want {
+		t.Fatalf("Num players: got %v, want %v", got, want)
+	}
+	for i, want := range wantPlayers {
+		p := g.Players[i]
+		if p.Name != want.name || p.Color != want.color || p.StartPos != want.startPos {
+			t.Errorf("Player %d: got %v %v %v, want %v %v %v", i, p.Name, p.Color, p.StartPos, want.name, want.color, want.startPos)
+		}
+	}
+	if got, want := g.CurrentPlayer().Color, Purple; got != want {
+		t.Errorf("First player color: got %v, want %v", got, want)
+	}
+
+	// First move must cover the interior starting square.
+	if _, err := g.Play(Move{Player: g.Players[0], PieceIndex: 0, Loc: Coord{0, 0}}); err == nil {
+		t.Error("Play() in the corner: got no error, want error")
+	}
+	playOrDie(t, g, Move{Player: g.Players[0], PieceIndex: 0, Loc: Coord{4, 4}})
+}
+
+func TestVariantNewGameFirstSeat(t *testing.T) {
+	v := DuoVariant()
+	v.FirstSeat = 1
+	g, err := v.NewGame(DefaultPieces(), []string{"foo", "bar"})
+	if err != nil {
+		t.Fatalf("NewGame(): got %v, want no error", err)
+	}
+	if got, want := g.CurPlayerIndex, 1; got != want {
+		t.Errorf("Player turn: got %v, want %v", got, want)
+	}
+	s, err := g.Setup().NewGame()
+	if err != nil {
+		t.Fatalf("Setup().NewGame(): got %v, want no error", err)
+	}
+	if got, want := s.CurPlayerIndex, 1; got != want {
+		t.Errorf("Player turn of game from setup: got %v, want %v", got, want)
+	}
+}
+
+func TestVariantNewGameWrongNumPlayers(t *testing.T) {
+	if _, err := ClassicVariant().NewGame(DefaultPieces(), []string{"foo", "bar"}); err == nil || !strings.Contains(err.Error(), "needs 4 players") {
+		t.Errorf("ClassicVariant().NewGame() with 2 players: got %v, want needs 4 players error", err)
+	}
+}
+
+func TestLookupVariant(t *testing.T) {
+	for _, name := range []string{"classic", "Duo"} {
+		if v, err := LookupVariant(name); err != nil || !strings.EqualFold(v.Name, name) {
+			t.Errorf("LookupVariant(%v): got %v, %v, want variant named %v", name, v, err, name)
+		}
+	}
+	if _, err := LookupVariant("hexagonal"); err == nil {
+		t.Error("LookupVariant(hexagonal): got no error, want error")
+	}
+}
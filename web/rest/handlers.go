@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -10,12 +11,37 @@ import (
 	"cloud.google.com/go/datastore"
 	"github.com/gorilla/mux"
 	"github.com/hueich/blokus"
+	"github.com/hueich/blokus/bot"
 )
 
 type gameInfo struct {
 	ID int64
 }
 
+type botMoveRequest struct {
+	// Bot is the kind of computer player, e.g. "random", "greedy" or "mcts".
+	Bot string
+}
+
+type newPlayerRequest struct {
+	// Username of the user joining the game.
+	Username string
+	// Colors the user wants to play. The next free color is chosen if empty.
+	Colors []blokus.Color
+}
+
+// getGame loads the game from datastore, with the players of its moves bound to the game's players.
+func (s *APIService) getGame(ctx context.Context, key *datastore.Key) (*blokus.Game, error) {
+	g := &blokus.Game{}
+	if err := s.client.Get(ctx, key, g); err != nil {
+		return nil, err
+	}
+	if err := g.BindMovePlayers(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
 func (s *APIService) getGamesHandler(w http.ResponseWriter, r *http.Request) {
 	q := datastore.NewQuery("Game")
 	q = q.KeysOnly()
@@ -78,14 +104,21 @@ func (s *APIService) getGameHandler(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("Invalid game ID"))
 		return
 	}
-	g := &blokus.Game{}
 	gameKey := datastore.IDKey("Game", gid, nil)
-	if err := s.client.Get(r.Context(), gameKey, g); err != nil {
+	g, err := s.getGame(r.Context(), gameKey)
+	if err != nil {
 		w.WriteHeader(http.StatusNotFound)
-		w.Write([]byte("No game found"))
+		w.Write([]byte(fmt.Sprintf("No game found: %v", err)))
+		return
+	}
+	b, err := json.Marshal(g)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Could not marshal game: %v\n", err)
 		return
 	}
-	w.Write([]byte(fmt.Sprintf("Got game: %v", *g)))
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.Write(b)
 }
 
 func (s *APIService) getGameStateHandler(w http.ResponseWriter, r *http.Request) {
@@ -93,9 +126,146 @@ func (s *APIService) getGameStateHandler(w http.ResponseWriter, r *http.Request)
 }
 
 func (s *APIService) newPlayerHandler(w http.ResponseWriter, r *http.Request) {
-	w.Write([]byte("Handled by newPlayerHandler()"))
+	vars := mux.Vars(r)
+	gid, err := strconv.ParseInt(vars["gid"], 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid game ID"))
+		return
+	}
+	req := &newPlayerRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid player request"))
+		return
+	}
+
+	gameKey := datastore.IDKey("Game", gid, nil)
+	g, err := s.getGame(r.Context(), gameKey)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("No game found: %v", err)))
+		return
+	}
+	if err := g.Join(req.Username, &blokus.PlayerOptions{Colors: req.Colors}); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("Could not add player: %v", err)))
+		return
+	}
+	if _, err := s.client.Put(r.Context(), gameKey, g); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Could not put game with new player: %v\n", err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
 }
 
 func (s *APIService) newMoveHandler(w http.ResponseWriter, r *http.Request) {
-	w.Write([]byte("Handled by newMoveHandler()"))
+	vars := mux.Vars(r)
+	gid, err := strconv.ParseInt(vars["gid"], 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid game ID"))
+		return
+	}
+	// The move is in its JSON format, with the player given by color, e.g.
+	// {"color": "blue", "piece": 3, "orient": "r1f", "loc": [4, 7]}.
+	m := blokus.Move{}
+	if err := json.NewDecoder(r.Body).Decode(&m); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("Invalid move request: %v", err)))
+		return
+	}
+
+	gameKey := datastore.IDKey("Game", gid, nil)
+	g, err := s.getGame(r.Context(), gameKey)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("No game found: %v", err)))
+		return
+	}
+	color := m.Player.Color
+	m.Player = nil
+	for _, p := range g.Players {
+		if p.Color == color {
+			m.Player = p
+		}
+	}
+	if m.Player == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("No player with color %v", color)))
+		return
+	}
+	res, err := g.Play(m)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("Could not play move: %v", err)))
+		return
+	}
+	if _, err := s.client.Put(r.Context(), gameKey, g); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Could not put game with new move: %v\n", err)
+		return
+	}
+
+	b, err := json.Marshal(res.Move)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Could not marshal move: %v\n", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(b)
+}
+
+func (s *APIService) botMoveHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	gid, err := strconv.ParseInt(vars["gid"], 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid game ID"))
+		return
+	}
+	req := &botMoveRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("Invalid bot move request"))
+		return
+	}
+	b, err := bot.Lookup(req.Bot)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	gameKey := datastore.IDKey("Game", gid, nil)
+	g, err := s.getGame(r.Context(), gameKey)
+	if err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(fmt.Sprintf("No game found: %v", err)))
+		return
+	}
+	res, err := bot.Play(r.Context(), g, b)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(fmt.Sprintf("Could not play computer move: %v", err)))
+		return
+	}
+	if _, err := s.client.Put(r.Context(), gameKey, g); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Could not put game with computer move: %v\n", err)
+		return
+	}
+
+	m, err := json.Marshal(res.Move)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		log.Printf("Could not marshal computer move: %v\n", err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(m)
 }
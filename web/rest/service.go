@@ -55,6 +55,8 @@ func (s *APIService) addRoutes(r *mux.Router) {
 	g.HandleFunc("/players", s.newPlayerHandler).Methods("POST")
 	// Make a move in the game.
 	g.HandleFunc("/moves", s.newMoveHandler).Methods("POST")
+	// Let a computer player make the move of the current player.
+	g.HandleFunc("/moves/bot", s.botMoveHandler).Methods("POST")
 }
 
 func (s *APIService) numGames(ctx context.Context) (int, error) {
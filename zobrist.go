@@ -0,0 +1,74 @@
+package blokus
+
+// Zobrist hashing gives every position a 64-bit key, by XORing a pseudo-random key for each colored cell,
+// for the player to move, and for each player no longer taking turns. Keys are derived from a fixed seed,
+// so the same position has the same hash in every process.
+
+const (
+	zobristSeed = 0x2545f4914f6cdd1d
+)
+
+// Kinds of keys, so that keys for cells, turns and statuses don't collide.
+const (
+	zobristCell = iota
+	zobristTurn
+	zobristStatus
+	zobristSize
+)
+
+// splitmix64 scrambles x into a well distributed 64-bit value.
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
+
+// zobristKey returns the key of the kind for the pair of values.
+func zobristKey(kind, a, b int) uint64 {
+	return splitmix64(zobristSeed ^ splitmix64(uint64(kind)<<56^uint64(a)<<16^uint64(b)))
+}
+
+// cellKey returns the key of a cell with the color, where the cell is given by its index in the grid.
+func cellKey(i int, color Color) uint64 {
+	if !color.IsColored() {
+		return 0
+	}
+	return zobristKey(zobristCell, i, int(color))
+}
+
+// Hash returns the Zobrist hash of the cells of the board. It's kept up to date by SetCell,
+// so it's cheap to call after every placement.
+func (b *Board) Hash() uint64 {
+	if !b.hashed {
+		b.hash = zobristKey(zobristSize, b.Height, b.Width)
+		for i, color := range b.Grid {
+			b.hash ^= cellKey(i, color)
+		}
+		b.hashed = true
+	}
+	return b.hash
+}
+
+// updateHash keeps the hash in sync after a cell changed from one color to another.
+func (b *Board) updateHash(i int, old, color Color) {
+	if b.hashed {
+		b.hash ^= cellKey(i, old) ^ cellKey(i, color)
+	}
+}
+
+// Hash returns the Zobrist hash of the position, i.e. the board, the player to move and the players who no longer
+// take turns. Games reaching the same position through different moves have the same hash.
+// The board's part of the hash is updated as pieces are placed or taken back, so this is cheap to call.
+func (g *Game) Hash() uint64 {
+	if g.Board == nil {
+		return 0
+	}
+	h := g.Board.Hash() ^ zobristKey(zobristTurn, g.CurPlayerIndex, len(g.Players))
+	for i, p := range g.Players {
+		if !p.Status.IsActive() {
+			h ^= zobristKey(zobristStatus, i, int(p.Status))
+		}
+	}
+	return h
+}
@@ -0,0 +1,118 @@
+package blokus
+
+import (
+	"testing"
+)
+
+// freshHash returns the hash of the game calculated from scratch.
+func freshHash(g *Game) uint64 {
+	b := *g.Board
+	b.hashed = false
+	c := *g
+	c.Board = &b
+	return c.Hash()
+}
+
+func TestHashIsIncremental(t *testing.T) {
+	g := newMidGame(t, 0)
+	g.Hash()
+	playRandomly(t, g, 30)
+	if got, want := g.Hash(), freshHash(g); got != want {
+		t.Errorf("Hash() after moves: got %x, want %x", got, want)
+	}
+	for i := 0; i < 10; i++ {
+		if _, err := g.Undo(); err != nil {
+			t.Fatalf("Undo(): got %v, want no error", err)
+		}
+		if got, want := g.Hash(), freshHash(g); got != want {
+			t.Errorf("Hash() after undo: got %x, want %x", got, want)
+		}
+	}
+}
+
+func TestHashOfTranspositions(t *testing.T) {
+	g1 := newMidGame(t, 0)
+	g2 := newMidGame(t, 0)
+	start := g1.Hash()
+	b1, y1, r1, gr1 := g1.Players[0], g1.Players[1], g1.Players[2], g1.Players[3]
+	b2, y2, r2, gr2 := g2.Players[0], g2.Players[1], g2.Players[2], g2.Players[3]
+
+	// Yellow's piece is placed before or after blue's second piece, reaching the same position.
+	for _, m := range []Move{
+		{Player: b1, PieceIndex: 0, Loc: Coord{0, 0}},
+		{Player: y1, PieceIndex: 0, Loc: Coord{0, 19}},
+		{Player: r1, PieceIndex: -1},
+		{Player: gr1, PieceIndex: -1},
+		{Player: b1, PieceIndex: 1, Loc: Coord{1, 1}},
+		{Player: y1, PieceIndex: -1},
+	} {
+		playOrDie(t, g1, m)
+	}
+	for _, m := range []Move{
+		{Player: b2, PieceIndex: 0, Loc: Coord{0, 0}},
+		{Player: y2, PieceIndex: -1},
+		{Player: r2, PieceIndex: -1},
+		{Player: gr2, PieceIndex: -1},
+		{Player: b2, PieceIndex: 1, Loc: Coord{1, 1}},
+		{Player: y2, PieceIndex: 0, Loc: Coord{0, 19}},
+	} {
+		playOrDie(t, g2, m)
+	}
+	if got, want := g1.Hash(), g2.Hash(); got != want {
+		t.Errorf("Hash() of transposed games: got %x and %x, want equal", got, want)
+	}
+	if g1.Hash() == start {
+		t.Error("Hash() after moves: got same as initial hash, want different")
+	}
+
+	h := g1.Hash()
+	g1.CurPlayerIndex = 3
+	if g1.Hash() == h {
+		t.Error("Hash() with another player to move: got same hash, want different")
+	}
+	g1.CurPlayerIndex = 2
+	if err := g1.Resign(r1); err != nil {
+		t.Fatalf("Resign(): got %v, want no error", err)
+	}
+	if g1.Hash() == h {
+		t.Error("Hash() after resigning: got same hash, want different")
+	}
+}
+
+func TestBoardHash(t *testing.T) {
+	b1, err := NewBoard(5)
+	if err != nil {
+		t.Fatalf("NewBoard(): got %v, want no error", err)
+	}
+	b2, err := NewBoard(5)
+	if err != nil {
+		t.Fatalf("NewBoard(): got %v, want no error", err)
+	}
+	empty := b1.Hash()
+	b1.SetCell(Coord{1, 2}, Red)
+	b1.SetCell(Coord{3, 3}, Blue)
+	b2.SetCell(Coord{3, 3}, Blue)
+	b2.SetCell(Coord{0, 0}, Green)
+	b2.SetCell(Coord{1, 2}, Red)
+	b2.SetCell(Coord{0, 0}, colorEmpty)
+	if got, want := b1.Hash(), b2.Hash(); got != want {
+		t.Errorf("Hash() of same cells set in different order: got %x and %x, want equal", got, want)
+	}
+	b1.SetCell(Coord{1, 2}, Yellow)
+	if got, want := b1.Hash(), b2.Hash(); got == want {
+		t.Errorf("Hash() of different colors: got %x and %x, want different", got, want)
+	}
+	b1.SetCell(Coord{1, 2}, colorEmpty)
+	b1.SetCell(Coord{3, 3}, colorEmpty)
+	if got := b1.Hash(); got != empty {
+		t.Errorf("Hash() after clearing all cells: got %x, want %x", got, empty)
+	}
+
+	b3, err := NewRectBoard(5, 6)
+	if err != nil {
+		t.Fatalf("NewRectBoard(): got %v, want no error", err)
+	}
+	if b3.Hash() == empty {
+		t.Error("Hash() of empty boards of different sizes: got same hash, want different")
+	}
+}